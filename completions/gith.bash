@@ -17,7 +17,7 @@ _gith() {
             return 0
             ;;
         config)
-            opts="show reset path update help"
+            opts="show reset path update validate help"
             COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
             return 0
             ;;
@@ -61,6 +61,16 @@ _gith() {
             COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
             return 0
             ;;
+        --persistent|--skipConfirm)
+            opts="true false"
+            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+            return 0
+            ;;
+        --ticketPlacement)
+            opts="scope prefix footer off"
+            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+            return 0
+            ;;
     esac
 }
 complete -F _gith gith
\ No newline at end of file
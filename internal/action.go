@@ -30,8 +30,10 @@ func (m Model) HandleActionSelection() (tea.Model, tea.Cmd) {
 		m.Selected = 0
 		m.CurrentStep = StepChanges
 		m.Level = 2
-		m.Err = "Changes will come here soon"
-		return m, tea.Quit
+	case "History":
+		m.Selected = 0
+		m.CurrentStep = StepHistory
+		m.Level = 2
 	case "Options":
 		m.Selected = 0
 		m.CurrentStep = StepOptions
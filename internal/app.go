@@ -3,12 +3,15 @@ package internal
 import (
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/a3chron/gith/internal/config"
+	"github.com/a3chron/gith/internal/git"
 	"github.com/a3chron/gith/internal/ui"
 )
 
@@ -24,6 +27,14 @@ func UpdateOnInit(skip bool) tea.Cmd {
 	}
 }
 
+// LoadRepoInfo loads the repository state for the header in the background
+func LoadRepoInfo() tea.Cmd {
+	return func() tea.Msg {
+		info, err := git.GetRepoInfo()
+		return RepoInfoMsg{Info: info, Err: err}
+	}
+}
+
 func (m Model) Init() tea.Cmd {
 	skipFetch := true
 
@@ -36,9 +47,16 @@ func (m Model) Init() tea.Cmd {
 		}
 	}
 
+	// the header is loaded right away and again once the fetch updated ahead / behind
+	update := UpdateOnInit(skipFetch)
+	if !skipFetch {
+		update = tea.Sequence(update, LoadRepoInfo())
+	}
+
 	return tea.Batch(
 		m.Spinner.Tick,
-		UpdateOnInit(skipFetch),
+		LoadRepoInfo(),
+		update,
 	)
 }
 
@@ -52,7 +70,34 @@ func (m Model) getCurrentOptions() []string {
 	case StepBranchSelect:
 		return m.BranchModel.Branches
 	case StepBranchCreate:
-		return m.BranchModel.Options
+		return m.branchNameOptions()
+	case StepBranchBase:
+		return m.BranchModel.Bases
+	case StepBranchBaseSelect:
+		return m.BranchModel.BaseRefs
+	case StepBranchSwitch:
+		return m.BranchModel.SwitchOptions
+
+	case StepCompareLeft:
+		return m.CompareModel.LeftRefs
+	case StepCompareRight:
+		return m.CompareModel.RightRefs
+	case StepCompareFiles:
+		return m.CompareModel.FileOptions
+
+	case StepChanges:
+		return m.ChangesModel.Actions
+	case StepChangesCommit:
+		return m.ChangesModel.Commits
+
+	case StepHistory:
+		return m.HistoryModel.Actions
+	case StepBlameFile:
+		return m.BlameModel.Files
+	case StepFileHistoryPath:
+		return m.FileHistoryModel.Paths
+	case StepFileHistory:
+		return m.FileHistoryModel.Options
 
 	case StepCommitAction:
 		return m.CommitModel.Actions
@@ -79,6 +124,9 @@ func (m Model) getCurrentOptions() []string {
 		return m.ConfigModel.Accents
 	case StepOptionsInitBehaviourSelect:
 		return m.ConfigModel.InitBehaviours
+
+	case StepConfirm:
+		return m.ConfirmModel.Options
 	default:
 		return []string{}
 	}
@@ -92,10 +140,32 @@ func (m *Model) resetState() {
 	m.BranchModel.SelectedAction = ""
 	m.BranchModel.SelectedOption = ""
 	m.BranchModel.Input = ""
+	m.BranchModel.DeleteRemote = false
+	m.BranchModel.BaseBranch = ""
+	m.BranchModel.SelectedBase = ""
+	m.BranchModel.BaseInput = ""
+	m.BranchModel.SelectedSwitch = ""
+	m.BranchModel.Template = ""
+	m.BranchModel.FieldValues = nil
+	m.BranchModel.FieldInput = ""
+	m.BranchModel.Current = ""
+	m.BranchModel.Upstream = ""
+
+	m.CompareModel = CompareModel{}
+	m.ChangesModel.SelectedAction = ""
+	m.ChangesModel.SelectedCommit = ""
+	m.ChangesModel.RangeInput = ""
+	m.DiffModel = DiffModel{}
+	m.HistoryModel.SelectedAction = ""
+	m.HistoryModel.SelectedCommit = ""
+	m.GraphModel = GraphModel{}
+	m.BlameModel = BlameModel{}
+	m.FileHistoryModel = FileHistoryModel{}
 
 	m.CommitModel.SelectedAction = ""
 	m.CommitModel.SelectedPrefix = ""
 	m.CommitModel.CommitMessage = ""
+	m.CommitModel.Ticket = ""
 
 	m.TagModel.SelectedAction = ""
 	m.TagModel.SelectedOption = ""
@@ -112,9 +182,16 @@ func (m *Model) resetState() {
 	m.ConfigModel.SelectedFlavor = ""
 	m.ConfigModel.SelectedBehaviour = ""
 	m.ConfigModel.SelectedAction = ""
+
+	m.Input = InputModel{}
+	m.Filter = FilterModel{}
+	m.ConfirmModel = ConfirmModel{}
+	m.Marked = nil
+
 	m.Selected = 0
 	m.Level = 1
 	m.Output = []string{} // TODO add m.Output[0] if exisiting
+	m.OutputScroll = 0
 	m.Err = ""
 	m.Success = ""
 	m.StartAt = ""
@@ -149,12 +226,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.HandleTagOperation()
 
 		case "switch-branch":
-			m.PopulateBranches()
-			m.Selected = 0
+			m.PrepareSwitchBranchSelection()
+			m.ActionModel.SelectedAction = "Branch"
+			m.BranchModel.SelectedAction = "Switch Branch"
+
+		case "switch-previous":
 			m.Level = 3
 			m.CurrentStep = StepBranchSelect
 			m.ActionModel.SelectedAction = "Branch"
 			m.BranchModel.SelectedAction = "Switch Branch"
+			previous, err := git.GetPreviousBranch()
+			if err != nil {
+				m.Err = err.Error()
+				return m, m.finish()
+			}
+			m.BranchModel.SelectedBranch = previous
+			return m.ExecuteBranchAction()
 
 		case "delete-branch":
 			m.PopulateBranches()
@@ -198,6 +285,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Level = 3
 			m.Selected = 0
 			m.CurrentStep = StepRemoteNameInput
+			m.startInput("", "e.g. origin", validateNoSpaces("Remote name"))
 			m.ActionModel.SelectedAction = "Remote"
 			m.RemoteModel.SelectedAction = "Add Remote"
 
@@ -215,113 +303,214 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case RepoInfoMsg:
+		m.RepoInfo.Loading = false
+		if msg.Err != nil {
+			m.RepoInfo.Err = "Failed to load repository state"
+		} else {
+			m.RepoInfo.Info = msg.Info
+			m.RepoInfo.Err = ""
+		}
+		return m, nil
+
+	case FlowFinishedMsg:
+		m.returnToMenu()
+		m.RepoInfo.Loading = true
+		return m, LoadRepoInfo()
+
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+		return m, nil
+
 	case spinner.TickMsg:
 		m.Spinner, _ = m.Spinner.Update(msg)
 		return m, m.Spinner.Tick
 
 	case tea.KeyMsg:
 		if isInputStep(m.CurrentStep) {
-			switch msg.String() {
-			case "ctrl+c", "esc":
+			switch {
+			case msg.Type == tea.KeyCtrlC, matchesInInput(msg, m.Keys.Quit):
 				m.Err = "User cancelled"
 				return m, tea.Quit
-			case "ctrl+h", "ctrl+y":
-				m.resetState()
-			case "enter":
-				switch m.CurrentStep {
-				case StepTagInput:
-					return m.HandleTagInputSubmit()
-				case StepBranchInput:
-					return m.HandleBranchInputSubmit()
-				case StepRemoteNameInput:
-					// proceed to URL input if name provided
-					if strings.TrimSpace(m.RemoteModel.NameInput) == "" {
-						m.Err = "Remote name cannot be empty"
-						return m, tea.Quit
-					}
-					m.CurrentStep = StepRemoteUrlInput
+			case matchesInInput(msg, m.Keys.Back):
+				return m.goBack(), nil
+			case matchesInInput(msg, m.Keys.Select):
+				if m.Input.Field.Err != nil {
+					// show the validation error instead of submitting
+					m.Input.Submitted = true
 					return m, nil
-				case StepRemoteUrlInput:
-					if strings.TrimSpace(m.RemoteModel.UrlInput) == "" {
-						m.Err = "Remote URL cannot be empty"
-						return m, tea.Quit
-					}
-					out, err := exec.Command("git", "remote", "add", m.RemoteModel.NameInput, m.RemoteModel.UrlInput).CombinedOutput()
-					if err != nil {
-						m.OutputByLevel(string(out))
-						m.Err = "Failed to add remote"
-					} else {
-						m.Success = fmt.Sprintf("Added Remote '%s' -> %s", m.RemoteModel.NameInput, m.RemoteModel.UrlInput)
-					}
-					return m, tea.Quit
-				case StepCommitInput:
-					return m.HandleCommitMessageSubmit()
-				}
-			case "backspace":
-				switch m.CurrentStep {
-				case StepTagInput:
-					if len(m.TagModel.ManualInput) > 0 {
-						m.TagModel.ManualInput = m.TagModel.ManualInput[:len(m.TagModel.ManualInput)-1]
-					}
-				case StepBranchInput:
-					if len(m.BranchModel.Input) > 0 {
-						m.BranchModel.Input = m.BranchModel.Input[:len(m.BranchModel.Input)-1]
-					}
-				case StepRemoteNameInput:
-					if len(m.RemoteModel.NameInput) > 0 {
-						m.RemoteModel.NameInput = m.RemoteModel.NameInput[:len(m.RemoteModel.NameInput)-1]
-					}
-				case StepRemoteUrlInput:
-					if len(m.RemoteModel.UrlInput) > 0 {
-						m.RemoteModel.UrlInput = m.RemoteModel.UrlInput[:len(m.RemoteModel.UrlInput)-1]
-					}
-				case StepCommitInput:
-					if len(m.CommitModel.CommitMessage) > 0 {
-						m.CommitModel.CommitMessage = m.CommitModel.CommitMessage[:len(m.CommitModel.CommitMessage)-1]
-					}
 				}
+				return m.withHistory(m.handleInputSubmit())
 			default:
-				// Add character to input
-				if len(msg.String()) == 1 {
-					switch m.CurrentStep {
-					case StepTagInput:
-						m.TagModel.ManualInput += msg.String()
-					case StepBranchInput:
-						m.BranchModel.Input += msg.String()
-					case StepRemoteNameInput:
-						m.RemoteModel.NameInput += msg.String()
-					case StepRemoteUrlInput:
-						m.RemoteModel.UrlInput += msg.String()
-					case StepCommitInput:
-						m.CommitModel.CommitMessage += msg.String()
-					}
-				}
+				// Everything else is handled by the text input (typing, cursor movement, paste, ...)
+				return m, m.updateInput(msg)
 			}
-			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c", "esc":
+		if m.Filter.Active {
+			return m.handleFilterKey(msg)
+		}
+
+		switch {
+		case msg.Type == tea.KeyCtrlC, key.Matches(msg, m.Keys.Quit):
 			m.Err = "User quit"
 			return m, tea.Quit
 
-		case "ctrl+h":
+		case key.Matches(msg, m.Keys.Back):
+			if m.CurrentStep == StepAction && m.Persistent {
+				// only the quit keys end a persistent session
+				return m, nil
+			}
 			if m.CurrentStep == StepAction || m.CurrentStep == StepLoad {
 				m.Err = "User quit"
 				return m, tea.Quit
 			}
-			m.resetState()
+			return m.goBack(), nil
 
-		case "up", "k", "down", "j":
-			m.handleNavigation(msg.String())
+		case m.CurrentStep == StepDiff && key.Matches(msg, m.Keys.Up, m.Keys.Down, m.Keys.PageUp, m.Keys.PageDown, m.Keys.Home, m.Keys.End, m.Keys.NextFile, m.Keys.PrevFile):
+			m.handleDiffKey(msg)
 
-		case "enter":
-			return m.handleEnterKey()
+		case m.CurrentStep == StepGraph && key.Matches(msg, m.Keys.Up, m.Keys.Down, m.Keys.PageUp, m.Keys.PageDown, m.Keys.Home, m.Keys.End):
+			m.handleGraphKey(msg)
+
+		case m.CurrentStep == StepBlame && key.Matches(msg, m.Keys.Up, m.Keys.Down, m.Keys.PageUp, m.Keys.PageDown, m.Keys.Home, m.Keys.End):
+			m.handleBlameKey(msg)
+
+		case m.CurrentStep == StepBlame && key.Matches(msg, m.Keys.Parent):
+			return m.BlameParent()
+
+		case key.Matches(msg, m.Keys.Up, m.Keys.Down, m.Keys.PageUp, m.Keys.PageDown, m.Keys.Home, m.Keys.End):
+			m.handleNavigation(msg)
+
+		case key.Matches(msg, m.Keys.Select):
+			return m.withHistory(m.handleEnterKey())
+
+		case key.Matches(msg, m.Keys.Toggle):
+			if m.isMultiSelectStep() {
+				m.toggleMark()
+			}
+
+		case key.Matches(msg, m.Keys.Previous):
+			if m.isSwitchBranchStep() {
+				return m.SwitchToPreviousBranch()
+			}
+
+		case key.Matches(msg, m.Keys.OutputUp, m.Keys.OutputDown):
+			m.scrollOutput(msg)
+
+		case key.Matches(msg, m.Keys.Filter):
+			if m.isFilterableStep() {
+				m.Filter = FilterModel{Active: true}
+				m.Selected = 0
+			}
+		}
+
+	default:
+		// e.g. the result of reading the clipboard on ctrl+v
+		if isInputStep(m.CurrentStep) {
+			return m, m.updateInput(msg)
 		}
 	}
 	return m, nil
 }
 
+// withHistory takes the result of a select and saves the state before it, so goBack can return to it.
+// Selects that stay on the same step (e.g. errors or ignored selects) save nothing,
+// so a single back still leaves the step.
+func (m Model) withHistory(next tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	var model *Model
+	switch n := next.(type) {
+	case Model:
+		model = &n
+	case *Model:
+		model = n
+	default:
+		return next, cmd
+	}
+
+	if model.CurrentStep == m.CurrentStep && model.Level == m.Level {
+		return model, cmd
+	}
+	m.pushHistory()
+	model.History = m.History
+	return model, cmd
+}
+
+// pushHistory saves the current state, so goBack can return to it.
+func (m *Model) pushHistory() {
+	snapshot := *m
+	snapshot.History = nil
+	snapshot.Output = slices.Clone(m.Output)
+	// the text input edits its value in place, give the snapshot its own copy
+	snapshot.Input.Field.SetValue(m.Input.Field.Value())
+	m.History = append(m.History, snapshot)
+}
+
+// goBack returns to the previous step with its selection restored.
+// Without history (e.g. in quick selects) it resets to the action selection.
+func (m Model) goBack() Model {
+	// undo any style preview of the accent / flavor selection
+	ui.UpdateStylesByConfig(m.CurrentConfig)
+
+	if len(m.History) == 0 {
+		m.resetState()
+		return m
+	}
+
+	prev := m.History[len(m.History)-1]
+	prev.History = m.History[:len(m.History)-1]
+	// the terminal size, header and last result are not part of the step
+	prev.Spinner = m.Spinner
+	prev.Width, prev.Height = m.Width, m.Height
+	prev.RepoInfo = m.RepoInfo
+	prev.LastResult = m.LastResult
+	return prev
+}
+
+// handleInputSubmit submits the value of the current input step
+func (m Model) handleInputSubmit() (tea.Model, tea.Cmd) {
+	switch m.CurrentStep {
+	case StepTagInput:
+		return m.HandleTagInputSubmit()
+	case StepBranchInput:
+		return m.HandleBranchInputSubmit()
+	case StepBranchTemplateInput:
+		return m.HandleBranchTemplateInputSubmit()
+	case StepBranchBaseInput:
+		return m.HandleBranchBaseInputSubmit()
+	case StepRemoteNameInput:
+		// proceed to URL input if name provided
+		if strings.TrimSpace(m.RemoteModel.NameInput) == "" {
+			m.Err = "Remote name cannot be empty"
+			return m, m.finish()
+		}
+		m.CurrentStep = StepRemoteUrlInput
+		m.startInput("", "e.g. git@github.com:a3chron/gith.git", validateNoSpaces("Remote URL"))
+		return m, nil
+	case StepRemoteUrlInput:
+		if strings.TrimSpace(m.RemoteModel.UrlInput) == "" {
+			m.Err = "Remote URL cannot be empty"
+			return m, m.finish()
+		}
+		out, err := exec.Command("git", "remote", "add", m.RemoteModel.NameInput, m.RemoteModel.UrlInput).CombinedOutput()
+		if err != nil {
+			m.OutputByLevel(string(out))
+			m.Err = "Failed to add remote"
+		} else {
+			m.Success = fmt.Sprintf("Added Remote '%s' -> %s", m.RemoteModel.NameInput, m.RemoteModel.UrlInput)
+		}
+		return m, m.finish()
+	case StepCommitInput:
+		return m.HandleCommitMessageSubmit()
+	case StepChangesRangeInput:
+		return m.HandleChangesRangeSubmit()
+	case StepFileHistoryInput:
+		return m.HandleFileHistoryInputSubmit()
+	}
+	return m, nil
+}
+
 func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 	switch m.CurrentStep {
 	case StepAction:
@@ -333,6 +522,37 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 		return m.HandleBranchSelection()
 	case StepBranchCreate:
 		return m.HandleBranchCreateSelection()
+	case StepBranchBase:
+		return m.HandleBranchBaseSelection()
+	case StepBranchBaseSelect:
+		return m.HandleBranchBaseRefSelection()
+	case StepBranchSwitch:
+		return m.HandleBranchSwitchSelection()
+
+	case StepCompareLeft:
+		return m.HandleCompareLeftSelection()
+	case StepCompareRight:
+		return m.HandleCompareRightSelection()
+	case StepCompareFiles:
+		return m.HandleCompareFileSelection()
+
+	case StepChanges:
+		return m.HandleChangesSelection()
+	case StepChangesCommit:
+		return m.HandleChangesCommitSelection()
+
+	case StepHistory:
+		return m.HandleHistorySelection()
+	case StepGraph:
+		return m.HandleGraphSelection()
+	case StepBlameFile:
+		return m.HandleBlameFileSelection()
+	case StepBlame:
+		return m.HandleBlameSelection()
+	case StepFileHistoryPath:
+		return m.HandleFileHistoryPathSelection()
+	case StepFileHistory:
+		return m.HandleFileHistorySelection()
 
 	case StepCommitAction:
 		return m.HandleCommitSelection()
@@ -359,39 +579,53 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 		return m.HandleOptionsAccentSelection()
 	case StepOptionsInitBehaviourSelect:
 		return m.HandleOptionsInitBehaviourSelection()
+
+	case StepConfirm:
+		return m.HandleConfirmSelection()
 	}
 	return m, nil
 }
 
-func (m *Model) handleNavigation(key string) {
-	options := m.getCurrentOptions()
+func (m *Model) handleNavigation(msg tea.KeyMsg) {
+	options := m.visibleOptions()
 	if len(options) == 0 {
+		// without a list, e.g. in the commit details, the keys scroll the output
+		m.scrollOutput(msg)
 		return
 	}
 
-	switch key {
-	case "up", "k":
+	switch {
+	case key.Matches(msg, m.Keys.Up):
 		if m.Selected > 0 {
 			m.Selected--
 		} else {
 			m.Selected = len(options) - 1
 		}
-	case "down", "j":
+	case key.Matches(msg, m.Keys.Down):
 		if m.Selected < len(options)-1 {
 			m.Selected++
 		} else {
 			m.Selected = 0
 		}
+	case key.Matches(msg, m.Keys.PageUp):
+		m.Selected = max(m.Selected-m.pageSize(), 0)
+	case key.Matches(msg, m.Keys.PageDown):
+		m.Selected = min(m.Selected+m.pageSize(), len(options)-1)
+	case key.Matches(msg, m.Keys.Home):
+		m.Selected = 0
+	case key.Matches(msg, m.Keys.End):
+		m.Selected = len(options) - 1
 	}
 
 	// handle preview when selecting a theme
-	if m.CurrentStep == StepOptionsAccentSelect {
+	index := m.selectedOptionIndex()
+	if m.CurrentStep == StepOptionsAccentSelect && index != -1 {
 		flavor := config.GetCatppuccinFlavor(m.CurrentConfig.Flavor)
-		ui.UpdateStyles(flavor, config.GetAccentColor(flavor, m.ConfigModel.Accents[m.Selected]))
+		ui.UpdateStyles(flavor, config.GetAccentColor(flavor, m.ConfigModel.Accents[index]))
 	}
 
-	if m.CurrentStep == StepOptionsFlavorSelect {
-		flavor := config.GetCatppuccinFlavor(m.ConfigModel.Flavors[m.Selected])
+	if m.CurrentStep == StepOptionsFlavorSelect && index != -1 {
+		flavor := config.GetCatppuccinFlavor(m.ConfigModel.Flavors[index])
 		ui.UpdateStyles(flavor, config.GetAccentColor(flavor, m.CurrentConfig.Accent))
 	}
 }
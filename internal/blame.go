@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/a3chron/gith/internal/git"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PrepareBlameFileSelection lists the tracked files to blame
+func (m *Model) PrepareBlameFileSelection() (*Model, tea.Cmd) {
+	files, err := git.GetTrackedFiles()
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if len(files) == 0 {
+		m.Err = "No tracked files to blame"
+		return m, m.finish()
+	}
+
+	m.BlameModel.Files = files
+	m.Selected = 0
+	m.CurrentStep = StepBlameFile
+	m.Level = 3
+	return m, nil
+}
+
+func (m Model) HandleBlameFileSelection() (tea.Model, tea.Cmd) {
+	m.BlameModel.File = m.BlameModel.Files[m.Selected]
+	m.BlameModel.Rev = ""
+	m.Level = 4
+
+	if err := m.loadBlame(0); err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	m.CurrentStep = StepBlame
+	return m, nil
+}
+
+// loadBlame blames the file at the current revision and selects the line closest to selected
+func (m *Model) loadBlame(selected int) error {
+	lines, err := git.GetBlame(m.BlameModel.Rev, m.BlameModel.File)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("%s is empty", m.BlameModel.File)
+	}
+
+	m.BlameModel.Lines = lines
+	m.BlameModel.Selected = min(max(selected, 0), len(lines)-1)
+	return nil
+}
+
+// handleBlameKey moves the selection between the lines of the blame
+func (m *Model) handleBlameKey(msg tea.KeyMsg) {
+	last := len(m.BlameModel.Lines) - 1
+
+	switch {
+	case key.Matches(msg, m.Keys.Up):
+		m.BlameModel.Selected = max(m.BlameModel.Selected-1, 0)
+	case key.Matches(msg, m.Keys.Down):
+		m.BlameModel.Selected = min(m.BlameModel.Selected+1, last)
+	case key.Matches(msg, m.Keys.PageUp):
+		m.BlameModel.Selected = max(m.BlameModel.Selected-m.pageSize(), 0)
+	case key.Matches(msg, m.Keys.PageDown):
+		m.BlameModel.Selected = min(m.BlameModel.Selected+m.pageSize(), last)
+	case key.Matches(msg, m.Keys.Home):
+		m.BlameModel.Selected = 0
+	case key.Matches(msg, m.Keys.End):
+		m.BlameModel.Selected = last
+	}
+}
+
+// HandleBlameSelection shows the details of the commit of the selected line
+func (m Model) HandleBlameSelection() (tea.Model, tea.Cmd) {
+	line := m.BlameModel.Lines[m.BlameModel.Selected]
+	if line.Uncommitted() {
+		// nothing to show yet
+		return m, nil
+	}
+	return m.ShowCommitDetails(line.Sha + " " + line.Summary)
+}
+
+// BlameParent blames the file again at the parent of the commit of the selected line,
+// to see what the line looked like before. Going back returns to the previous blame.
+func (m Model) BlameParent() (tea.Model, tea.Cmd) {
+	line := m.BlameModel.Lines[m.BlameModel.Selected]
+	if line.Uncommitted() || line.Previous == "" {
+		// uncommitted lines and lines of the first commit have no parent
+		return m, nil
+	}
+
+	m.pushHistory()
+	m.BlameModel.Rev = line.Previous
+	// PreviousFile is relative to the repository root, the blame runs in the current directory
+	file, err := filepath.Rel(git.GetPathPrefix(), line.PreviousFile)
+	if err != nil {
+		file = ":(top)" + line.PreviousFile
+	}
+	m.BlameModel.File = file
+	if err := m.loadBlame(m.BlameModel.Selected); err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	return m, nil
+}
+
+// blameColors assigns each commit of the blame a color index, in order of appearance
+func (m Model) blameColors() map[string]int {
+	colors := map[string]int{}
+	for _, line := range m.BlameModel.Lines {
+		if _, ok := colors[line.Sha]; !ok {
+			colors[line.Sha] = len(colors)
+		}
+	}
+	return colors
+}
@@ -1,10 +1,14 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/a3chron/gith/internal/config"
 	"github.com/a3chron/gith/internal/git"
+	"github.com/a3chron/gith/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -14,7 +18,58 @@ func (m Model) HandleBranchActionSelection() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) HandleBranchSelection() (tea.Model, tea.Cmd) {
-	m.BranchModel.SelectedBranch = m.BranchModel.Branches[m.Selected]
+	if len(m.Marked) > 0 {
+		var names []string
+		for _, option := range m.Marked {
+			names = append(names, branchName(option))
+		}
+		m.BranchModel.SelectedBranch = strings.Join(names, ", ")
+
+		var extra []string
+		if m.offersRemoteDelete() && slices.ContainsFunc(names, hasRemoteBranch) {
+			extra = append(extra, alsoDeleteRemote)
+		}
+
+		details := batchBranchDeleteDetails(m.Marked)
+		if m.BranchModel.SelectedAction == "Clean Up Merged" {
+			details = mergedBranchDeleteDetails(m.Marked, m.BranchModel.BaseBranch)
+		}
+		return m.confirm(fmt.Sprintf("Delete %d branches?", len(m.Marked)), details, "delete-branches", extra...)
+	}
+
+	option := m.BranchModel.Branches[m.Selected]
+	m.BranchModel.SelectedBranch = branchName(option)
+
+	switch m.BranchModel.SelectedAction {
+	case "Tracking":
+		if option == unsetUpstream {
+			m.BranchModel.SelectedBranch = option
+		}
+		return m.ExecuteTracking()
+
+	case "Push Branch":
+		m.BranchModel.SelectedBranch = option
+		return m.ExecutePush()
+
+	case "Rename Branch":
+		// the new name is entered like a new branch, with the prefix options
+		m.Selected = 0
+		m.CurrentStep = StepBranchCreate
+		m.Level = 4
+		return m, nil
+
+	case "Delete Branch", "Prune Stale Branches", "Clean Up Merged":
+		var extra []string
+		if m.offersRemoteDelete() && hasRemoteBranch(m.BranchModel.SelectedBranch) {
+			extra = append(extra, alsoDeleteRemote)
+		}
+
+		details := branchDeleteDetails(m.BranchModel.SelectedBranch)
+		if m.BranchModel.SelectedAction == "Clean Up Merged" {
+			details = mergedBranchDeleteDetails([]string{option}, m.BranchModel.BaseBranch)
+		}
+		return m.confirm(fmt.Sprintf("Delete branch '%s'?", m.BranchModel.SelectedBranch), details, "delete-branch", extra...)
+	}
 	return m.ExecuteBranchAction()
 }
 
@@ -26,7 +81,7 @@ func (m *Model) PopulateBranches() (tea.Model, tea.Cmd) {
 	}
 	if len(branches) == 0 {
 		m.Err = "No branches available"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	m.BranchModel.Branches = branches
@@ -38,21 +93,224 @@ func (m *Model) HandleBranchOperation() (*Model, tea.Cmd) {
 	case "Create Branch":
 		return m.PrepareBranchAddition()
 
-	case "Switch Branch", "Delete Branch":
+	case "Switch Branch":
+		return m.PrepareSwitchBranchSelection()
+
+	case "Delete Branch":
 		m.PopulateBranches()
 		m.Selected = 0
 		m.CurrentStep = StepBranchSelect
 		m.Level = 3
 
+	case "Prune Stale Branches":
+		return m.PrepareStaleBranchSelection()
+
+	case "Tracking":
+		return m.PrepareTrackingSelection()
+
+	case "Compare":
+		return m.PrepareCompare()
+
+	case "Push Branch":
+		return m.PreparePush()
+
+	case "Clean Up Merged":
+		return m.PrepareMergedBranchSelection()
+
+	case "Rename Branch":
+		branches, err := git.GetLocalBranches()
+		if err != nil {
+			m.Err = fmt.Sprintf("%v", err)
+			return m, m.finish()
+		}
+		m.BranchModel.Branches = strings.Split(branches, "\n")
+		m.Selected = 0
+		m.CurrentStep = StepBranchSelect
+		m.Level = 3
+
 	case "List Branches":
 		m.PopulateBranches()
 		m.OutputByLevel(strings.Join(m.BranchModel.Branches, "\n"))
 		m.Success = "Listed branches"
-		return m, tea.Quit
+		return m, m.finish()
+	}
+	return m, nil
+}
+
+// PrepareSwitchBranchSelection lists the branches to switch to, the most recently checked out first.
+// The previous branch (see SwitchToPreviousBranch) is at the top.
+func (m *Model) PrepareSwitchBranchSelection() (*Model, tea.Cmd) {
+	m.PopulateBranches()
+	m.Selected = 0
+	m.CurrentStep = StepBranchSelect
+	m.Level = 3
+
+	recent, err := git.GetRecentBranches()
+	if err != nil {
+		return m, nil
+	}
+
+	var branches []string
+	for _, branch := range recent {
+		if slices.Contains(m.BranchModel.Branches, branch) {
+			branches = append(branches, branch)
+		}
+	}
+	for _, branch := range m.BranchModel.Branches {
+		if !slices.Contains(branches, branch) {
+			branches = append(branches, branch)
+		}
+	}
+
+	if previous, err := git.GetPreviousBranch(); err == nil && len(branches) > 0 && branches[0] == previous {
+		branches[0] += " (previous)"
+	}
+	m.BranchModel.Branches = branches
+	return m, nil
+}
+
+// isSwitchBranchStep returns true while selecting the branch to switch to
+func (m Model) isSwitchBranchStep() bool {
+	return m.CurrentStep == StepBranchSelect && m.BranchModel.SelectedAction == "Switch Branch"
+}
+
+// SwitchToPreviousBranch switches back to the branch checked out before the current one, like `git switch -`
+func (m Model) SwitchToPreviousBranch() (tea.Model, tea.Cmd) {
+	previous, err := git.GetPreviousBranch()
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+
+	m.pushHistory()
+	m.BranchModel.SelectedBranch = previous
+	return m.ExecuteBranchAction()
+}
+
+// PrepareStaleBranchSelection lists the local branches whose upstream is gone after pruning
+func (m *Model) PrepareStaleBranchSelection() (*Model, tea.Cmd) {
+	out, err := git.GetStaleBranches()
+	if err != nil {
+		m.OutputByLevel("\\crError:\n" + out)
+		m.Err = "Failed to fetch remotes"
+		return m, m.finish()
+	}
+
+	if out == "" {
+		m.Success = "No stale branches, every upstream still exists"
+		return m, m.finish()
+	}
+
+	var branches []string
+	for line := range strings.SplitSeq(out, "\n") {
+		branch, date, _ := strings.Cut(line, " ")
+		branches = append(branches, fmt.Sprintf("%s (last commit %s)", branch, date))
+	}
+
+	m.BranchModel.Branches = branches
+	m.Selected = 0
+	m.CurrentStep = StepBranchSelect
+	m.Level = 3
+	return m, nil
+}
+
+// PrepareMergedBranchSelection lists the local branches that are merged or squash merged
+// into the default branch. The current and protected branches are never listed.
+func (m *Model) PrepareMergedBranchSelection() (*Model, tea.Cmd) {
+	current, err := git.GetCurrentBranch()
+	if err != nil {
+		m.Err = current
+		return m, m.finish()
+	}
+
+	// compare with the default branch of the remote the current branch tracks
+	remote, _, _ := git.GetUpstreamRef(current)
+	if remote == "" {
+		remote = "origin"
+	}
+
+	base, err := git.GetDefaultBranch(remote)
+	if err != nil {
+		m.OutputByLevel("\\cp" + err.Error())
+		m.Err = "Failed to detect the default branch"
+		return m, m.finish()
+	}
+	m.BranchModel.BaseBranch = base
+
+	protected := append([]string{current, strings.TrimPrefix(base, remote+"/")}, m.CurrentConfig.ProtectedBranches...)
+
+	locals, err := git.GetLocalBranches()
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return m, m.finish()
+	}
+	merged, err := git.GetMergedBranches(base)
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return m, m.finish()
+	}
+	mergedBranches := strings.Split(merged, "\n")
+
+	var branches []string
+	for branch := range strings.SplitSeq(locals, "\n") {
+		if branch == "" || slices.Contains(protected, branch) {
+			continue
+		}
+
+		if slices.Contains(mergedBranches, branch) {
+			branches = append(branches, branch+" (merged)")
+		} else if squashed, err := git.IsSquashMerged(branch, base); err == nil && squashed {
+			branches = append(branches, branch+" (squash merged)")
+		}
 	}
+
+	if len(branches) == 0 {
+		m.Success = fmt.Sprintf("No branches merged into %s", base)
+		return m, m.finish()
+	}
+
+	m.BranchModel.Branches = branches
+	m.Selected = 0
+	m.CurrentStep = StepBranchSelect
+	m.Level = 3
 	return m, nil
 }
 
+// offersRemoteDelete returns true for actions that offer to delete the remote branches as well
+func (m Model) offersRemoteDelete() bool {
+	return m.BranchModel.SelectedAction == "Delete Branch" || m.BranchModel.SelectedAction == "Clean Up Merged"
+}
+
+// branchName returns the branch of a branch option, e.g. "feat/a" for "feat/a (last commit 2 days ago)"
+func branchName(option string) string {
+	name, _, _ := strings.Cut(option, " ")
+	return name
+}
+
+// remoteBranchOf returns the remote and branch on the remote tracked by a local branch,
+// empty if it has no upstream or the upstream is gone
+func remoteBranchOf(branch string) (string, string) {
+	remote, remoteBranch, err := git.GetUpstreamRef(branch)
+	if err != nil || remote == "" || !git.RefExists("refs/remotes/"+remote+"/"+remoteBranch) {
+		return "", ""
+	}
+	return remote, remoteBranch
+}
+
+func hasRemoteBranch(branch string) bool {
+	remote, _ := remoteBranchOf(branch)
+	return remote != ""
+}
+
+// deleteRemoteBranch deletes the remote branch of a local branch, if chosen in the confirmation.
+// remote and remoteBranch have to be looked up before the local branch is deleted.
+func (m *Model) deleteRemoteBranch(remote string, remoteBranch string) (string, error) {
+	if !m.BranchModel.DeleteRemote || remote == "" {
+		return "", nil
+	}
+	return git.DeleteRemoteBranch(remote, remoteBranch)
+}
+
 func (m *Model) PrepareBranchAddition() (*Model, tea.Cmd) {
 	m.Selected = 0
 	m.CurrentStep = StepBranchCreate
@@ -73,28 +331,81 @@ func (m *Model) ExecuteBranchAction() (*Model, tea.Cmd) {
 			m.Success = "Switched Branch"
 		}
 
-		return m, tea.Quit
+		return m, m.finish()
 
-	case "Delete Branch":
+	case "Clean Up Merged":
+		// merged into the default branch, but maybe not into the current one, so -d could refuse
+		return m.ExecuteForceBranchDelete()
+
+	case "Delete Branch", "Prune Stale Branches":
+		remote, remoteBranch := remoteBranchOf(m.BranchModel.SelectedBranch)
 		out, err := git.DeleteBranch(m.BranchModel.SelectedBranch)
 
+		if err != nil && git.IsNotFullyMerged(m.BranchModel.SelectedBranch) {
+			// offer to force delete, showing what would be lost
+			m.askConfirmation(fmt.Sprintf("'%s' is not fully merged, force delete?", m.BranchModel.SelectedBranch),
+				forceDeleteDetails(m.BranchModel.SelectedBranch), "force-delete-branch")
+			return m, nil
+		}
+
+		if err == nil {
+			remoteOut, remoteErr := m.deleteRemoteBranch(remote, remoteBranch)
+			out += remoteOut
+			if remoteErr != nil {
+				m.OutputByLevel(out)
+				m.Err = fmt.Sprintf("Deleted Branch, but failed to delete %s/%s", remote, remoteBranch)
+				return m, m.finish()
+			}
+		}
+
 		m.OutputByLevel(out)
 
 		if err != nil {
 			m.Err = "Failed to Delete Branch"
+		} else if m.BranchModel.DeleteRemote && remote != "" {
+			m.Success = fmt.Sprintf("Deleted Branch and %s/%s", remote, remoteBranch)
 		} else {
 			m.Success = "Deleted Branch"
 		}
 
-		return m, tea.Quit
+		return m, m.finish()
+	}
+	return m, m.finish()
+}
+
+// branchNameOptions returns the name options of Create Branch and Rename Branch,
+// Create Branch offers the configured templates first
+func (m Model) branchNameOptions() []string {
+	if m.BranchModel.SelectedAction != "Create Branch" {
+		return m.BranchModel.Options
 	}
-	return m, tea.Quit
+	return append(slices.Clone(m.CurrentConfig.BranchTemplates), m.BranchModel.Options...)
 }
 
 func (m Model) HandleBranchCreateSelection() (tea.Model, tea.Cmd) {
-	m.BranchModel.SelectedOption = m.BranchModel.Options[m.Selected]
+	m.BranchModel.SelectedOption = m.branchNameOptions()[m.Selected]
 
-	if m.BranchModel.SelectedOption == "Manual Input" {
+	if fields, ok := config.TemplateFields(m.BranchModel.SelectedOption); ok && m.BranchModel.SelectedAction == "Create Branch" {
+		m.BranchModel.Template = m.BranchModel.SelectedOption
+		m.BranchModel.TemplateFields = fields
+		m.BranchModel.FieldValues = nil
+		m.Selected = 0
+		m.CurrentStep = StepBranchTemplateInput
+		m.startTemplateField()
+		return m, nil
+	}
+
+	if m.BranchModel.SelectedAction == "Rename Branch" {
+		// keep the name, but replace its prefix with the chosen one
+		name := m.BranchModel.SelectedBranch
+		if m.BranchModel.SelectedOption != "Manual Input" {
+			if _, rest, found := strings.Cut(name, "/"); found {
+				name = rest
+			}
+			name = m.BranchModel.SelectedOption + name
+		}
+		m.BranchModel.Input = name
+	} else if m.BranchModel.SelectedOption == "Manual Input" {
 		// Switch to input mode
 		m.BranchModel.Input = ""
 	} else {
@@ -104,6 +415,82 @@ func (m Model) HandleBranchCreateSelection() (tea.Model, tea.Cmd) {
 
 	m.Selected = 0
 	m.CurrentStep = StepBranchInput
+	m.startBranchNameInput()
+	return m, nil
+}
+
+// startBranchNameInput asks for the (prefilled) branch name, which is checked while typing
+func (m *Model) startBranchNameInput() {
+	validate := validateNewBranchName
+	if m.BranchModel.SelectedAction == "Rename Branch" {
+		validate = validateBranchName
+	}
+	m.startInput(m.BranchModel.Input, "e.g. feat/new-feature", validate)
+}
+
+// startTemplateField asks for the next field of the selected branch template
+func (m *Model) startTemplateField() {
+	field := m.BranchModel.TemplateFields[len(m.BranchModel.FieldValues)]
+	m.BranchModel.FieldInput = ""
+
+	placeholder := "optional"
+	switch field {
+	case "type":
+		placeholder = "e.g. feat, tab to complete"
+	case "slug":
+		placeholder = "e.g. Add login page"
+	}
+	m.startInput("", placeholder, validateTemplateField(field))
+
+	if field == "type" {
+		// suggest the prefixes of the static options
+		var types []string
+		for _, option := range m.BranchModel.Options {
+			if prefix, found := strings.CutSuffix(option, "/"); found {
+				types = append(types, prefix)
+			}
+		}
+		m.Input.Field.ShowSuggestions = true
+		m.Input.Field.CompletionStyle = ui.DimStyle
+		m.Input.Field.SetSuggestions(types)
+	}
+}
+
+// templateValues returns the template fields entered so far, including the current input
+func (m Model) templateValues() map[string]string {
+	values := make(map[string]string)
+	for i, value := range m.BranchModel.FieldValues {
+		values[m.BranchModel.TemplateFields[i]] = value
+	}
+	if m.CurrentStep == StepBranchTemplateInput && len(m.BranchModel.FieldValues) < len(m.BranchModel.TemplateFields) {
+		field := m.BranchModel.TemplateFields[len(m.BranchModel.FieldValues)]
+		values[field] = templateFieldValue(field, m.BranchModel.FieldInput)
+	}
+	return values
+}
+
+// templateFieldValue slugifies the title entered for {slug}, other fields are used as entered
+func templateFieldValue(field string, value string) string {
+	if field == "slug" {
+		return slugify(value)
+	}
+	return strings.TrimSpace(value)
+}
+
+func (m *Model) HandleBranchTemplateInputSubmit() (*Model, tea.Cmd) {
+	field := m.BranchModel.TemplateFields[len(m.BranchModel.FieldValues)]
+	m.BranchModel.FieldValues = append(slices.Clone(m.BranchModel.FieldValues), templateFieldValue(field, m.BranchModel.FieldInput))
+
+	if len(m.BranchModel.FieldValues) < len(m.BranchModel.TemplateFields) {
+		m.startTemplateField()
+		return m, nil
+	}
+
+	// all fields are filled, show the name for a last check
+	m.BranchModel.Input = fillBranchTemplate(m.BranchModel.Template, m.templateValues())
+	m.CurrentStep = StepBranchInput
+	m.startBranchNameInput()
+	m.Input.Submitted = true
 	return m, nil
 }
 
@@ -111,18 +498,291 @@ func (m Model) HandleBranchCreateSelection() (tea.Model, tea.Cmd) {
 func (m *Model) HandleBranchInputSubmit() (*Model, tea.Cmd) {
 	if strings.TrimSpace(m.BranchModel.Input) == "" {
 		m.Err = "Branch name cannot be empty"
-		return m, tea.Quit
+		return m, m.finish()
+	}
+
+	if m.BranchModel.SelectedAction == "Rename Branch" {
+		return m.ExecuteBranchRename()
+	}
+
+	return m.PrepareBranchBaseSelection()
+}
+
+// PrepareBranchBaseSelection asks where the new branch should start
+func (m *Model) PrepareBranchBaseSelection() (*Model, tea.Cmd) {
+	head := "Current HEAD"
+	if current, err := git.GetCurrentBranch(); err == nil && current != "" {
+		head += " (" + current + ")"
+	}
+
+	bases := []string{head}
+	if remote, base, err := defaultBranchBase(); err == nil {
+		if remote != "" {
+			base += ", fetched"
+		}
+		bases = append(bases, "Default branch ("+base+")")
+	}
+	m.BranchModel.Bases = append(bases, "Branch", "Tag", "Commit")
+
+	m.Selected = 0
+	m.CurrentStep = StepBranchBase
+	m.Level = 4
+	return m, nil
+}
+
+// defaultBranchBase returns the default branch to start new branches from.
+// remote is set if it is a remote branch (e.g. origin/main), which should be fetched first.
+func defaultBranchBase() (string, string, error) {
+	remote := "origin"
+	if current, err := git.GetCurrentBranch(); err == nil {
+		if upstreamRemote, _, _ := git.GetUpstreamRef(current); upstreamRemote != "" {
+			remote = upstreamRemote
+		}
+	}
+
+	base, err := git.GetDefaultBranch(remote)
+	if err != nil {
+		return "", "", err
+	}
+
+	// prefer the remote branch, a local default branch might be outdated
+	branch := strings.TrimPrefix(base, remote+"/")
+	if git.RefExists("refs/remotes/" + remote + "/" + branch) {
+		return remote, remote + "/" + branch, nil
+	}
+	return "", base, nil
+}
+
+func (m Model) HandleBranchBaseSelection() (tea.Model, tea.Cmd) {
+	m.BranchModel.SelectedBase = m.BranchModel.Bases[m.Selected]
+	kind, _, _ := strings.Cut(m.BranchModel.SelectedBase, " (")
+
+	switch kind {
+	case "Current HEAD":
+		m.BranchModel.BaseBranch = ""
+		return m.PrepareBranchSwitchSelection()
+
+	case "Default branch":
+		remote, base, err := defaultBranchBase()
+		if err != nil {
+			m.Err = err.Error()
+			return m, m.finish()
+		}
+		if remote != "" {
+			out, err := git.FetchBranch(remote, strings.TrimPrefix(base, remote+"/"))
+			if err != nil {
+				m.OutputByLevel(out)
+				m.Err = err.Error()
+				return m, m.finish()
+			}
+		}
+		m.BranchModel.BaseBranch = base
+		return m.PrepareBranchSwitchSelection()
+
+	case "Branch", "Tag":
+		var refs string
+		var err error
+		if kind == "Branch" {
+			refs, err = git.GetAllBranchRefs()
+		} else {
+			refs, err = git.GetAllTags()
+		}
+		if err != nil {
+			m.Err = err.Error()
+			return m, m.finish()
+		}
+		if refs == "" {
+			m.Err = fmt.Sprintf("No %ss found", strings.ToLower(kind))
+			return m, m.finish()
+		}
+
+		m.BranchModel.BaseRefs = strings.Split(refs, "\n")
+		m.Selected = 0
+		m.CurrentStep = StepBranchBaseSelect
+		m.Level = 5
+		return m, nil
+
+	case "Commit":
+		m.Selected = 0
+		m.CurrentStep = StepBranchBaseInput
+		m.Level = 5
+		m.startInput("", "e.g. 1a2b3c4 or HEAD~2", validateCommit)
+		return m, nil
 	}
 
-	out, err := git.CreateBranch(strings.TrimSpace(m.BranchModel.Input))
+	return m, m.finish()
+}
+
+func (m Model) HandleBranchBaseRefSelection() (tea.Model, tea.Cmd) {
+	m.BranchModel.BaseBranch = m.BranchModel.BaseRefs[m.Selected]
+	return m.PrepareBranchSwitchSelection()
+}
+
+func (m *Model) HandleBranchBaseInputSubmit() (*Model, tea.Cmd) {
+	m.BranchModel.BaseBranch = strings.TrimSpace(m.BranchModel.BaseInput)
+	return m.PrepareBranchSwitchSelection()
+}
+
+// validateCommit checks that the input names a commit, e.g. a (short) SHA or HEAD~2
+func validateCommit(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("Commit cannot be empty")
+	}
+	if !git.IsCommit(value) {
+		return fmt.Errorf("'%s' is not a commit", value)
+	}
+	return nil
+}
+
+// PrepareBranchSwitchSelection asks whether to switch to the new branch, one level below the base
+func (m *Model) PrepareBranchSwitchSelection() (*Model, tea.Cmd) {
+	m.Selected = 0
+	m.CurrentStep = StepBranchSwitch
+	m.Level++
+	return m, nil
+}
+
+func (m Model) HandleBranchSwitchSelection() (tea.Model, tea.Cmd) {
+	m.BranchModel.SelectedSwitch = m.BranchModel.SwitchOptions[m.Selected]
+	return m.ExecuteBranchCreate()
+}
+
+// ExecuteBranchCreate creates the new branch at the chosen base
+func (m *Model) ExecuteBranchCreate() (*Model, tea.Cmd) {
+	name := strings.TrimSpace(m.BranchModel.Input)
+	switchTo := m.BranchModel.SelectedSwitch == "Create and switch to it"
+
+	out, err := git.CreateBranch(name, m.BranchModel.BaseBranch, switchTo)
 
 	m.OutputByLevel(out)
 
 	if err != nil {
 		m.Err = "Failed to Create Branch"
+		return m, m.finish()
+	}
+
+	m.Success = fmt.Sprintf("Created Branch '%s'", name)
+	if m.BranchModel.BaseBranch != "" {
+		m.Success += " from " + m.BranchModel.BaseBranch
+	}
+	if switchTo {
+		m.Success += " and switched to it"
+	}
+	return m, m.finish()
+}
+
+// ExecuteBranchRename renames the selected branch locally and offers to rename its remote branch as well
+func (m *Model) ExecuteBranchRename() (*Model, tea.Cmd) {
+	oldName := m.BranchModel.SelectedBranch
+	newName := strings.TrimSpace(m.BranchModel.Input)
+	if newName == oldName {
+		m.Err = "The new name is the same as the old one"
+		return m, m.finish()
+	}
+
+	remote, remoteBranch := remoteBranchOf(oldName)
+
+	out, err := git.RenameBranch(oldName, newName)
+	if err != nil {
+		m.OutputByLevel(out)
+		m.Err = "Failed to Rename Branch"
+		return m, m.finish()
+	}
+
+	if remote == "" {
+		m.Success = fmt.Sprintf("Renamed Branch to '%s'", newName)
+		return m, m.finish()
+	}
+
+	m.askConfirmation(fmt.Sprintf("Renamed locally, rename %s/%s as well?", remote, remoteBranch), []string{
+		fmt.Sprintf("Pushes %s to %s/%s", newName, remote, newName),
+		fmt.Sprintf("Deletes %s/%s", remote, remoteBranch),
+		fmt.Sprintf("Sets the upstream of %s to %s/%s", newName, remote, newName),
+	}, "rename-remote-branch")
+	return m, nil
+}
+
+// ExecuteRemoteBranchRename pushes the renamed branch, deletes the old remote branch and resets the upstream
+func (m *Model) ExecuteRemoteBranchRename() (*Model, tea.Cmd) {
+	newName := strings.TrimSpace(m.BranchModel.Input)
+
+	// git branch -m keeps the upstream, so it still points to the old remote branch
+	remote, oldRemoteBranch := remoteBranchOf(newName)
+
+	out, err := git.PushBranch(remote, newName)
+	if err != nil {
+		m.OutputByLevel(out)
+		m.Err = fmt.Sprintf("Renamed Branch, but failed to push it to %s", remote)
+		return m, m.finish()
+	}
+
+	deleteOut, err := git.DeleteRemoteBranch(remote, oldRemoteBranch)
+	m.OutputByLevel(out + deleteOut)
+	if err != nil {
+		m.Err = fmt.Sprintf("Renamed Branch, but failed to delete %s/%s", remote, oldRemoteBranch)
+		return m, m.finish()
+	}
+
+	m.Success = fmt.Sprintf("Renamed Branch to '%s' locally and on %s", newName, remote)
+	return m, m.finish()
+}
+
+// ExecuteForceBranchDelete deletes the selected branch with `git branch -D`
+func (m *Model) ExecuteForceBranchDelete() (*Model, tea.Cmd) {
+	remote, remoteBranch := remoteBranchOf(m.BranchModel.SelectedBranch)
+	out, err := git.ForceDeleteBranch(m.BranchModel.SelectedBranch)
+
+	verb := "Force Deleted"
+	if m.BranchModel.SelectedAction == "Clean Up Merged" {
+		verb = "Deleted"
+	}
+
+	if err == nil {
+		remoteOut, remoteErr := m.deleteRemoteBranch(remote, remoteBranch)
+		out += remoteOut
+		if remoteErr != nil {
+			m.OutputByLevel(out)
+			m.Err = fmt.Sprintf("%s Branch, but failed to delete %s/%s", verb, remote, remoteBranch)
+			return m, m.finish()
+		}
+	}
+
+	m.OutputByLevel(out)
+
+	if err != nil {
+		m.Err = "Failed to Force Delete Branch"
+	} else if m.BranchModel.DeleteRemote && remote != "" {
+		m.Success = fmt.Sprintf("%s Branch and %s/%s", verb, remote, remoteBranch)
 	} else {
-		m.Success = "Created Branch"
+		m.Success = verb + " Branch"
+	}
+
+	return m, m.finish()
+}
+
+// ExecuteBatchBranchDelete deletes all marked branches and shows a summary
+func (m *Model) ExecuteBatchBranchDelete() (*Model, tea.Cmd) {
+	var result batchResult
+	for _, option := range m.Marked {
+		branch := branchName(option)
+		remote, remoteBranch := remoteBranchOf(branch)
+
+		var out string
+		var err error
+		if m.BranchModel.SelectedAction == "Clean Up Merged" {
+			out, err = git.ForceDeleteBranch(branch)
+		} else {
+			out, err = git.DeleteBranch(branch)
+		}
+		result.add(branch, out, err)
+
+		if err == nil && m.BranchModel.DeleteRemote && remote != "" {
+			out, err := m.deleteRemoteBranch(remote, remoteBranch)
+			result.add(remote+"/"+remoteBranch, out, err)
+		}
 	}
 
-	return m, tea.Quit
+	result.finish(m, "Deleted", "Branches")
+	return m, m.finish()
 }
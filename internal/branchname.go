@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/a3chron/gith/internal/config"
+	"github.com/a3chron/gith/internal/git"
+	"golang.org/x/text/unicode/norm"
+)
+
+// slugify turns a free-text title into a branch name part, e.g. "Add Login page!" -> "add-login-page".
+// Accents are dropped ("café" -> "cafe"), other letters are kept as git accepts them.
+func slugify(title string) string {
+	var slug strings.Builder
+	dash := false
+	var last rune
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// drop the accents of latin letters, but keep marks like the dakuten of "デ"
+			if !dash && last > unicode.MaxASCII {
+				slug.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+			last = r
+		default:
+			dash = true
+		}
+	}
+	return norm.NFC.String(slug.String())
+}
+
+// fillBranchTemplate replaces the fields of a template with their values.
+// A field without a value is left out together with the separator after it
+// (or before it, for the last field), e.g. "{type}/{ticket}-{slug}" without a ticket becomes "feat/add-login".
+func fillBranchTemplate(template string, values map[string]string) string {
+	matches := config.TemplateField.FindAllStringSubmatchIndex(template, -1)
+
+	var name strings.Builder
+	start := 0
+	for i, match := range matches {
+		name.WriteString(template[start:match[0]])
+		start = match[1]
+
+		if value := values[template[match[2]:match[3]]]; value != "" {
+			name.WriteString(value)
+			continue
+		}
+
+		nextEnd := len(template)
+		if i+1 < len(matches) {
+			nextEnd = matches[i+1][0]
+		}
+		if next := template[start:nextEnd]; next != "" && isTemplateSeparator(next[0]) {
+			start++
+		} else if next == "" && i == len(matches)-1 {
+			filled := name.String()
+			if filled != "" && isTemplateSeparator(filled[len(filled)-1]) {
+				name.Reset()
+				name.WriteString(filled[:len(filled)-1])
+			}
+		}
+	}
+	name.WriteString(template[start:])
+
+	return name.String()
+}
+
+func isTemplateSeparator(c byte) bool {
+	return strings.IndexByte("-_./", c) != -1
+}
+
+// validateBranchName checks a branch name against the rules of git check-ref-format,
+// with a readable message for the common mistakes
+func validateBranchName(value string) error {
+	return checkBranchName(value, "Branch name")
+}
+
+// checkBranchName checks a branch name or a part of it, name is used in the error messages
+func checkBranchName(value string, name string) error {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return errors.New(name + " cannot be empty")
+	case strings.ContainsAny(value, " \t"):
+		return errors.New(name + " cannot contain spaces")
+	case strings.ContainsFunc(value, unicode.IsControl):
+		return errors.New(name + " cannot contain control characters")
+	case strings.ContainsAny(value, "~^:?*[\\"):
+		return errors.New(name + " cannot contain any of ~ ^ : ? * [ \\")
+	case strings.HasPrefix(value, "-"):
+		return errors.New(name + " cannot start with '-'")
+	case value == "@":
+		return errors.New(name + " cannot be '@'")
+	case strings.Contains(value, ".."):
+		return errors.New(name + " cannot contain '..'")
+	case strings.Contains(value, "@{"):
+		return errors.New(name + " cannot contain '@{'")
+	case strings.HasPrefix(value, "/"), strings.HasSuffix(value, "/"), strings.Contains(value, "//"):
+		return errors.New(name + " cannot start or end with '/' or contain '//'")
+	case strings.HasSuffix(value, "."):
+		return errors.New(name + " cannot end with '.'")
+	}
+
+	for part := range strings.SplitSeq(value, "/") {
+		if strings.HasPrefix(part, ".") {
+			return errors.New(name + " cannot have parts starting with '.'")
+		}
+		if strings.HasSuffix(part, ".lock") {
+			return errors.New(name + " cannot have parts ending with '.lock'")
+		}
+	}
+
+	// git has the last word, e.g. for rules added in newer versions
+	if !git.IsValidBranchName(value) {
+		return fmt.Errorf("'%s' is not valid in a branch name", value)
+	}
+	return nil
+}
+
+// validateNewBranchName additionally checks that the branch does not exist yet
+func validateNewBranchName(value string) error {
+	if err := validateBranchName(value); err != nil {
+		return err
+	}
+	if name := strings.TrimSpace(value); git.RefExists("refs/heads/" + name) {
+		return fmt.Errorf("Branch '%s' already exists", name)
+	}
+	return nil
+}
+
+// validateTemplateField checks a single field of a branch template, fields can be left empty
+func validateTemplateField(field string) func(string) error {
+	return func(value string) error {
+		value = strings.TrimSpace(value)
+		if value == "" || field == "slug" {
+			return nil
+		}
+		label := strings.ToUpper(field[:1]) + field[1:]
+		if strings.Contains(value, "/") {
+			return errors.New(label + " cannot contain '/'")
+		}
+		return checkBranchName(value, label)
+	}
+}
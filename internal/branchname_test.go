@@ -0,0 +1,119 @@
+package internal
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Add Login page!", "add-login-page"},
+		{"  --fix   the  bug--  ", "fix-the-bug"},
+		{"Café crème", "cafe-creme"},
+		{"Straße über Öl", "straße-uber-ol"},
+		{"データベース 移行", "データベース-移行"},
+		{"Привет, мир", "привет-мир"},
+		{"v2.0 release", "v2-0-release"},
+		{"!!!", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := slugify(tt.title); got != tt.want {
+			t.Errorf("slugify(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestFillBranchTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		values   map[string]string
+		want     string
+	}{
+		{
+			name:     "all fields",
+			template: "{type}/{ticket}-{slug}",
+			values:   map[string]string{"type": "feat", "ticket": "ABC-1", "slug": "add-login"},
+			want:     "feat/ABC-1-add-login",
+		},
+		{
+			name:     "empty field drops the separator after it",
+			template: "{type}/{ticket}-{slug}",
+			values:   map[string]string{"type": "feat", "slug": "add-login"},
+			want:     "feat/add-login",
+		},
+		{
+			name:     "empty first field",
+			template: "{type}/{ticket}-{slug}",
+			values:   map[string]string{"ticket": "ABC-1", "slug": "add-login"},
+			want:     "ABC-1-add-login",
+		},
+		{
+			name:     "empty last field drops the separator before it",
+			template: "{type}/{slug}-{ticket}",
+			values:   map[string]string{"type": "fix", "slug": "crash"},
+			want:     "fix/crash",
+		},
+		{
+			name:     "all fields empty",
+			template: "{type}/{ticket}-{slug}",
+			values:   map[string]string{},
+			want:     "",
+		},
+		{
+			name:     "literal text is kept",
+			template: "user/{slug}",
+			values:   map[string]string{"slug": "try-things"},
+			want:     "user/try-things",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fillBranchTemplate(tt.template, tt.values); got != tt.want {
+				t.Errorf("fillBranchTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"feat/add-login", false},
+		{"fix/ABC-1-crash", false},
+		{"feat/データベース", false},
+		{"", true},
+		{"   ", true},
+		{"add login", true},
+		{"feat..login", true},
+		{"feat@{1}", true},
+		{"feat/login.lock", true},
+		{"feat.lock/login", true},
+		{"-feat", true},
+		{"@", true},
+		{"feat/", true},
+		{"/feat", true},
+		{"feat//login", true},
+		{"feat.", true},
+		{"feat/.login", true},
+		{"feat~1", true},
+		{"feat^", true},
+		{"feat:login", true},
+		{"feat?", true},
+		{"feat*", true},
+		{"feat[1]", true},
+		{"feat\\login", true},
+		{"feat\x7f", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateBranchName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("validateBranchName(%q) = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxChangesCommits is the number of recent commits offered by Changes -> Commit
+const maxChangesCommits = 100
+
+func (m Model) HandleChangesSelection() (tea.Model, tea.Cmd) {
+	m.ChangesModel.SelectedAction = m.ChangesModel.Actions[m.Selected]
+
+	switch m.ChangesModel.SelectedAction {
+	case "Unstaged Changes":
+		diff, err := git.GetWorktreeDiff()
+		return m.showChanges(diff, err, "No unstaged changes")
+
+	case "Staged Changes":
+		diff, err := git.GetStagedDiff()
+		return m.showChanges(diff, err, "No staged changes")
+
+	case "Commit":
+		commits, err := git.GetRecentCommits(maxChangesCommits)
+		if err != nil {
+			m.Err = err.Error()
+			return m, m.finish()
+		}
+		if len(commits) == 0 {
+			m.Err = "No commits yet"
+			return m, m.finish()
+		}
+		m.ChangesModel.Commits = commits
+		m.Selected = 0
+		m.CurrentStep = StepChangesCommit
+		m.Level = 3
+
+	case "Range":
+		m.CurrentStep = StepChangesRangeInput
+		m.Level = 3
+		m.startInput("", "e.g. main..feat/a or HEAD~3", validateRange)
+	}
+	return m, nil
+}
+
+func (m Model) HandleChangesCommitSelection() (tea.Model, tea.Cmd) {
+	m.ChangesModel.SelectedCommit = m.ChangesModel.Commits[m.Selected]
+
+	sha := branchName(m.ChangesModel.SelectedCommit)
+	diff, err := git.GetCommitDiff(sha)
+	return m.showChanges(diff, err, fmt.Sprintf("Commit %s has no changes", sha))
+}
+
+func (m *Model) HandleChangesRangeSubmit() (*Model, tea.Cmd) {
+	diffRange := strings.TrimSpace(m.ChangesModel.RangeInput)
+	m.ChangesModel.RangeInput = diffRange
+
+	diff, err := git.GetDiff(diffRange)
+	return m.showChanges(diff, err, "No changes in "+diffRange)
+}
+
+// showChanges opens a diff in the diff pane, empty is the message for a diff without changes
+func (m *Model) showChanges(diff string, err error, empty string) (*Model, tea.Cmd) {
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if !m.openDiff(diff) {
+		m.Success = empty
+		return m, m.finish()
+	}
+	return m, nil
+}
+
+// validateRange checks that the input is a revision or a range, e.g. "main..feat/a"
+func validateRange(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("Range cannot be empty")
+	}
+	if !git.IsValidRange(value) {
+		return fmt.Errorf("'%s' is not a revision or range", value)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/a3chron/gith/internal/git"
@@ -12,14 +13,7 @@ func (m Model) HandleCommitSelection() (tea.Model, tea.Cmd) {
 	m.CommitModel.SelectedAction = m.CommitModel.Actions[m.Selected]
 
 	if m.CommitModel.SelectedAction == "Undo Last Commit" {
-		out, err := git.UndoLastCommit()
-		m.OutputByLevel(out)
-		if err != nil {
-			m.Err = fmt.Sprintf("%v", err)
-		} else {
-			m.Success = "Undo Commit Successful"
-		}
-		return m, tea.Quit
+		return m.confirm("Undo the last commit?", undoCommitDetails(), "undo-commit")
 	}
 
 	m.Level = 3
@@ -30,21 +24,86 @@ func (m Model) HandleCommitSelection() (tea.Model, tea.Cmd) {
 
 func (m Model) HandleCommitPrefixSelection() (tea.Model, tea.Cmd) {
 	m.CommitModel.SelectedPrefix = m.CommitModel.CommitPrefixes[m.Selected]
+	m.CommitModel.Ticket = m.ticketFromBranch()
 
-	if m.CommitModel.SelectedPrefix != "Custom Prefix" {
+	custom := m.CommitModel.SelectedPrefix == "Custom Prefix"
+	ticket := m.CommitModel.Ticket
+	switch {
+	case ticket != "" && m.CurrentConfig.TicketPlacement == "scope" && !custom:
+		m.CommitModel.CommitMessage = m.CommitModel.SelectedPrefix + "(" + ticket + "): "
+	case ticket != "" && m.CurrentConfig.TicketPlacement == "prefix" && !custom:
+		m.CommitModel.CommitMessage = m.CommitModel.SelectedPrefix + ": " + ticket + " "
+	case ticket != "" && m.CurrentConfig.TicketPlacement == "prefix":
+		m.CommitModel.CommitMessage = ticket + " "
+	case !custom:
 		m.CommitModel.CommitMessage = m.CommitModel.SelectedPrefix + ": "
 	}
 
 	m.Selected = 0
 	m.CurrentStep = StepCommitInput
+	m.startInput(m.CommitModel.CommitMessage, "describe your changes", validateNotEmpty("Commit message"))
 	return m, nil
 }
 
+// ticketFromBranch returns the ticket ID in the current branch name, found with the configured pattern.
+// If the pattern has a group, the first group is the ticket ID.
+func (m Model) ticketFromBranch() string {
+	if m.CurrentConfig.TicketPlacement == "off" || m.CurrentConfig.TicketPattern == "" {
+		return ""
+	}
+
+	branch, err := git.GetCurrentBranch()
+	if err != nil || branch == "" {
+		return ""
+	}
+
+	pattern, err := regexp.Compile(m.CurrentConfig.TicketPattern)
+	if err != nil {
+		return ""
+	}
+
+	match := pattern.FindStringSubmatch(branch)
+	switch {
+	case match == nil:
+		return ""
+	case len(match) > 1 && match[1] != "":
+		return match[1]
+	default:
+		return match[0]
+	}
+}
+
+// addsTicketFooter returns true if the ticket ID is added as a "Refs:" footer on submit.
+// Custom prefixes have no scope, so the footer is used for them as well.
+func (m Model) addsTicketFooter() bool {
+	if m.CommitModel.Ticket == "" || strings.Contains(m.CommitModel.CommitMessage, m.CommitModel.Ticket) {
+		return false
+	}
+	placement := m.CurrentConfig.TicketPlacement
+	return placement == "footer" || placement == "scope" && m.CommitModel.SelectedPrefix == "Custom Prefix"
+}
+
+func (m *Model) ExecuteUndoCommit() (*Model, tea.Cmd) {
+	out, err := git.UndoLastCommit()
+	m.OutputByLevel(out)
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+	} else {
+		m.Success = "Undo Commit Successful"
+	}
+	return m, m.finish()
+}
+
 // function to handle commit message submission
 func (m *Model) HandleCommitMessageSubmit() (*Model, tea.Cmd) {
 	if strings.TrimSpace(m.CommitModel.CommitMessage) == "" {
 		m.Err = "Commit message cannot be empty"
-		return m, tea.Quit
+		return m, m.finish()
+	}
+
+	message := m.CommitModel.CommitMessage
+	if m.addsTicketFooter() {
+		message = strings.TrimRight(message, " \n") + "\n\nRefs: " + m.CommitModel.Ticket
 	}
 
 	var out string
@@ -52,9 +111,9 @@ func (m *Model) HandleCommitMessageSubmit() (*Model, tea.Cmd) {
 
 	switch m.CommitModel.SelectedAction {
 	case "Commit Staged":
-		out, err = git.CommitStaged(m.CommitModel.CommitMessage)
+		out, err = git.CommitStaged(message)
 	case "Commit All":
-		out, err = git.CommitAll(m.CommitModel.CommitMessage)
+		out, err = git.CommitAll(message)
 	}
 
 	if err != nil {
@@ -64,5 +123,5 @@ func (m *Model) HandleCommitMessageSubmit() (*Model, tea.Cmd) {
 		m.Success = "Commited Changes"
 	}
 
-	return m, tea.Quit
+	return m, m.finish()
 }
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PrepareCompare lists the refs to compare, the current branch first
+func (m *Model) PrepareCompare() (*Model, tea.Cmd) {
+	refs, err := compareRefs()
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return m, m.finish()
+	}
+
+	var options []string
+	if current, err := git.GetCurrentBranch(); err == nil && current != "" {
+		options = append(options, current+" (current)")
+		refs = slices.DeleteFunc(refs, func(ref string) bool { return ref == current })
+	}
+	m.CompareModel.LeftRefs = append(options, refs...)
+
+	m.Selected = 0
+	m.CurrentStep = StepCompareLeft
+	m.Level = 3
+	return m, nil
+}
+
+// compareRefs returns all local and remote branches and tags
+func compareRefs() ([]string, error) {
+	branches, err := git.GetAllBranchRefs()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := git.GetAllTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	for ref := range strings.SplitSeq(branches+"\n"+tags, "\n") {
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+func (m Model) HandleCompareLeftSelection() (tea.Model, tea.Cmd) {
+	m.CompareModel.Left = branchName(m.CompareModel.LeftRefs[m.Selected])
+
+	refs, err := compareRefs()
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return m, m.finish()
+	}
+	refs = slices.DeleteFunc(refs, func(ref string) bool { return ref == m.CompareModel.Left })
+
+	// compare with the default branch unless chosen otherwise
+	var options []string
+	if _, base, err := defaultBranchBase(); err == nil && base != m.CompareModel.Left {
+		options = append(options, base+" (default branch)")
+		refs = slices.DeleteFunc(refs, func(ref string) bool { return ref == base })
+	}
+	m.CompareModel.RightRefs = append(options, refs...)
+
+	m.Selected = 0
+	m.CurrentStep = StepCompareRight
+	m.Level = 4
+	return m, nil
+}
+
+// HandleCompareRightSelection shows the commits unique to each side and their merge base,
+// then lists the files changed on the first ref since the merge base
+func (m Model) HandleCompareRightSelection() (tea.Model, tea.Cmd) {
+	left := m.CompareModel.Left
+	right := branchName(m.CompareModel.RightRefs[m.Selected])
+	m.CompareModel.Right = right
+
+	mergeBase, err := git.GetMergeBase(left, right)
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+
+	var lines []string
+	lines = append(lines, "\\ctMerge base: "+mergeBase)
+	for _, side := range [][2]string{{left, right}, {right, left}} {
+		commits, err := git.GetCommitsOnly(side[0], side[1])
+		if err != nil {
+			m.Err = err.Error()
+			return m, m.finish()
+		}
+		if commits == "" {
+			lines = append(lines, "No commits only on "+side[0])
+			continue
+		}
+		lines = append(lines, itemListDetails(strings.Split(commits, "\n"), "\\cp",
+			"commit only on "+side[0], "commits only on "+side[0])...)
+	}
+	m.OutputByLevel(strings.Join(lines, "\n"))
+
+	stats, err := git.GetDiffStat(m.compareRange())
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if len(stats) == 0 {
+		m.Success = fmt.Sprintf("No changes on %s since it forked from %s", left, right)
+		return m, m.finish()
+	}
+
+	m.CompareModel.Files = stats
+	m.CompareModel.FileOptions = nil
+	for _, stat := range stats {
+		m.CompareModel.FileOptions = append(m.CompareModel.FileOptions, formatFileStat(stat))
+	}
+
+	m.Selected = 0
+	m.CurrentStep = StepCompareFiles
+	m.Level = 5
+	return m, nil
+}
+
+// compareRange is the diff of the changes on the first ref since it forked from the second one, like a pull request
+func (m Model) compareRange() string {
+	return m.CompareModel.Right + "..." + m.CompareModel.Left
+}
+
+// formatFileStat formats a file of a diffstat, e.g. "+12 -3  internal/app.go"
+func formatFileStat(stat git.FileStat) string {
+	path := stat.Path
+	if stat.OldPath != "" {
+		path = stat.OldPath + " → " + stat.Path
+	}
+	if stat.Additions < 0 {
+		return fmt.Sprintf("%-9s %s", "binary", path)
+	}
+	return fmt.Sprintf("%-9s %s", fmt.Sprintf("+%d -%d", stat.Additions, stat.Deletions), path)
+}
+
+// HandleCompareFileSelection opens the diff of all changed files at the selected one,
+// going back returns to the files
+func (m Model) HandleCompareFileSelection() (tea.Model, tea.Cmd) {
+	stat := m.CompareModel.Files[m.Selected]
+	m.CompareModel.SelectedFile = m.CompareModel.FileOptions[m.Selected]
+
+	diff, err := git.GetDiff(m.compareRange())
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if !m.openDiff(diff) {
+		m.Success = fmt.Sprintf("No changes on %s since it forked from %s", m.CompareModel.Left, m.CompareModel.Right)
+		return m, m.finish()
+	}
+	m.showDiffFile(stat.Path)
+	return m, nil
+}
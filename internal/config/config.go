@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -12,15 +13,56 @@ import (
 )
 
 type Config struct {
+	Version       int    `json:"version"`
 	Accent        string `json:"accent"`
 	Flavor        string `json:"flavor"`
 	InitBehaviour string `json:"init"`
+	Persistent    bool   `json:"persistent"`
+	SkipConfirm   bool   `json:"skipConfirm"`
+	Keymap        Keymap `json:"keymap"`
+
+	// ProtectedBranches are never offered for cleanup
+	ProtectedBranches []string `json:"protectedBranches"`
+
+	// BranchTemplates are offered by Create Branch, e.g. "{type}/{ticket}-{slug}"
+	BranchTemplates []string `json:"branchTemplates"`
+
+	// TicketPattern finds the ticket ID in the current branch name (the first group, if it has one),
+	// TicketPlacement decides where commit messages get it
+	TicketPattern   string `json:"ticketPattern"`
+	TicketPlacement string `json:"ticketPlacement"`
+
+	// issues found while loading, see Issues
+	issues []Issue
+
+	// overrides remembers which keys were set from environment variables,
+	// so SaveConfig can write the file values back instead of the env ones
+	overrides map[string]envOverride
+
+	// updated holds the keys changed after loading, see MarkUpdated
+	updated map[string]bool
 }
 
 var DefaultConfig = Config{
+	Version:       CurrentVersion,
 	Accent:        "Blue",
 	Flavor:        "Mocha",
 	InitBehaviour: "Do not fetch for Quick Selects",
+	Keymap:        DefaultKeymap,
+
+	ProtectedBranches: []string{"main", "master", "develop"},
+	BranchTemplates:   []string{"{type}/{slug}"},
+	TicketPattern:     `[A-Z][A-Z0-9]+-[0-9]+`,
+	TicketPlacement:   "footer",
+}
+
+// NewDefaultConfig returns a copy of DefaultConfig that can be modified safely
+func NewDefaultConfig() *Config {
+	config := DefaultConfig
+	config.Keymap = DefaultKeymap.clone()
+	config.ProtectedBranches = slices.Clone(DefaultConfig.ProtectedBranches)
+	config.BranchTemplates = slices.Clone(DefaultConfig.BranchTemplates)
+	return &config
 }
 
 // GetConfigPath returns the path to the config file
@@ -51,7 +93,9 @@ func LoadConfig() (*Config, error) {
 		if err := SaveConfig(&DefaultConfig); err != nil {
 			return nil, fmt.Errorf("failed to create default config: %w", err)
 		}
-		return &DefaultConfig, nil
+		config := NewDefaultConfig()
+		applyEnvOverrides(config)
+		return config, nil
 	}
 
 	// Read existing config
@@ -60,45 +104,200 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
+	raw, err := parseRaw(data)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := migrate(raw)
+
+	config, err := resolve(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if migrated {
+		// Save the migrated file as it is, invalid values stay for `gith config validate`
+		if err := saveRaw(raw); err != nil {
+			return nil, fmt.Errorf("failed to save migrated config: %w", err)
+		}
+	}
+
+	applyEnvOverrides(config)
+
+	return config, nil
+}
+
+// Validate checks the config file and the GITH_* environment variables
+// and reports every invalid or unknown key. The file is not modified.
+func Validate() ([]Issue, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err == nil {
+		raw, err := parseRaw(data)
+		if err != nil {
+			return nil, err
+		}
+		migrate(raw)
+		issues = validate(raw)
+	}
+
+	return append(issues, validateEnv()...), nil
+}
+
+// Issues returns the invalid or unknown keys found while loading the config.
+// Invalid values have been replaced by their defaults.
+func (c *Config) Issues() []Issue {
+	return c.issues
+}
+
+func parseRaw(data []byte) (map[string]any, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+	return raw, nil
+}
+
+// resolve builds a config from a migrated raw config. Invalid values fall back to their defaults
+// and are kept as issues, `gith config validate` reports them. raw is not modified.
+func resolve(raw map[string]any) (*Config, error) {
+	issues := validate(raw)
 
-	// Validate config values
-	if !IsValidFlavor(config.Flavor) {
-		config.Flavor = DefaultConfig.Flavor
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	valid, err := parseRaw(data)
+	if err != nil {
+		return nil, err
+	}
+	dropInvalid(valid, issues)
+
+	config, err := fromRaw(valid)
+	if err != nil {
+		return nil, err
 	}
-	if !IsValidAccent(config.Accent) {
-		config.Accent = DefaultConfig.Accent
+	config.issues = issues
+	return config, nil
+}
+
+// fromRaw builds a config from a validated raw config, using defaults for missing keys
+func fromRaw(raw map[string]any) (*Config, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	if !IsValidInitBehaviour(config.InitBehaviour) {
-		config.InitBehaviour = DefaultConfig.InitBehaviour
+	config := NewDefaultConfig()
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	return &config, nil
+	// Values are valid at this point, but may differ in case
+	config.Flavor, _ = NormalizeFlavor(config.Flavor)
+	config.Accent, _ = NormalizeAccent(config.Accent)
+	config.InitBehaviour, _ = ParseInitBehaviour(config.InitBehaviour)
+	config.TicketPlacement, _ = ParseTicketPlacement(config.TicketPlacement)
+
+	return config, nil
 }
 
 // SaveConfig saves configuration to file
 func SaveConfig(config *Config) error {
+	// Marshal config to JSON, without values that only came from the environment
+	out := withoutEnvOverrides(config)
+	out.Version = CurrentVersion
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return writeConfigFile(data)
+}
+
+// SaveUpdates writes only the keys marked with MarkUpdated to the config file,
+// all other keys, including invalid and unknown ones, are kept as they are
+func SaveUpdates(config *Config) error {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return err
 	}
 
-	// Create config directory if it doesn't exist
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return SaveConfig(config)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw, err := parseRaw(data)
+	if err != nil {
+		return err
+	}
+	migrate(raw)
+
+	if err := applyUpdates(raw, config); err != nil {
+		return err
+	}
+
+	return saveRaw(raw)
+}
+
+// applyUpdates sets the keys marked with MarkUpdated in the raw config to their value in config
+func applyUpdates(raw map[string]any, config *Config) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	values, err := parseRaw(data)
+	if err != nil {
+		return err
 	}
 
-	// Marshal config to JSON
-	data, err := json.MarshalIndent(config, "", "  ")
+	for key := range config.updated {
+		if value, ok := lookup(values, key); ok {
+			store(raw, key, value)
+		}
+	}
+	return nil
+}
+
+// saveRaw saves a raw config to file, including unknown and invalid keys
+func saveRaw(raw map[string]any) error {
+	data, err := json.MarshalIndent(raw, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	return writeConfigFile(data)
+}
+
+func writeConfigFile(data []byte) error {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// Create config directory if it doesn't exist
+	configDir := filepath.Dir(configPath)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
 	// Write to file
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -183,6 +382,101 @@ func IsValidInitBehaviour(behaviour string) bool {
 	return slices.Contains(validBehaviours, behaviour)
 }
 
+// ParseInitBehaviour accepts the short cli names (always, quick, never)
+// as well as the full behaviour names and returns the full name
+func ParseInitBehaviour(behaviour string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(behaviour)) {
+	case "always", "always fetch on init":
+		return "Always fetch on Init", true
+	case "quick", "do not fetch for quick selects":
+		return "Do not fetch for Quick Selects", true
+	case "never", "never fetch":
+		return "Never fetch", true
+	}
+	return "", false
+}
+
+// ParseBool accepts true/false, yes/no, on/off and 1/0 and returns "true" or "false"
+func ParseBool(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "on", "1":
+		return "true", true
+	case "false", "no", "off", "0":
+		return "false", true
+	}
+	return "", false
+}
+
+// ParseList parses a comma separated list, e.g. "main,develop"
+func ParseList(value string) (string, bool) {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, ","), len(items) > 0
+}
+
+// TemplateField matches a field of a branch template, e.g. "{ticket}"
+var TemplateField = regexp.MustCompile(`\{([a-zA-Z]+)\}`)
+
+// TemplateFields returns the fields of a branch template in order of appearance,
+// e.g. type, ticket and slug for "{type}/{ticket}-{slug}".
+// Templates without fields or with unmatched braces are invalid.
+func TemplateFields(template string) ([]string, bool) {
+	if strings.ContainsAny(TemplateField.ReplaceAllString(template, ""), "{}") {
+		return nil, false
+	}
+
+	var fields []string
+	for _, match := range TemplateField.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(fields, match[1]) {
+			fields = append(fields, match[1])
+		}
+	}
+	return fields, len(fields) > 0
+}
+
+// ParseBranchTemplates parses a comma separated list of branch templates
+func ParseBranchTemplates(value string) (string, bool) {
+	templates, ok := ParseList(value)
+	if !ok {
+		return "", false
+	}
+	for template := range strings.SplitSeq(templates, ",") {
+		if _, ok := TemplateFields(template); !ok {
+			return "", false
+		}
+	}
+	return templates, true
+}
+
+// ParseTicketPattern checks that a ticket pattern is a valid regular expression
+func ParseTicketPattern(pattern string) (string, bool) {
+	if strings.TrimSpace(pattern) == "" {
+		return "", false
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", false
+	}
+	return pattern, true
+}
+
+// ParseTicketPlacement accepts scope, prefix, footer and off (case insensitive)
+func ParseTicketPlacement(placement string) (string, bool) {
+	placement = strings.ToLower(strings.TrimSpace(placement))
+	if slices.Contains(GetTicketPlacements(), placement) {
+		return placement, true
+	}
+	return "", false
+}
+
+// GetTicketPlacements returns where a ticket ID can be put in commit messages
+func GetTicketPlacements() []string {
+	return []string{"scope", "prefix", "footer", "off"}
+}
+
 // GetAvailableFlavors returns list of available flavors
 func GetAvailableFlavors() []string {
 	return []string{"Latte", "Frappe", "Macchiato", "Mocha"}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// envField maps a config key to the environment variable that can override it
+type envField struct {
+	Key   string
+	Env   string
+	parse func(value string) (string, bool)
+	get   func(c *Config) string
+	set   func(c *Config, value string)
+
+	// list returns the field of list keys, so the file value is restored as it was
+	list func(c *Config) *[]string
+}
+
+type envOverride struct {
+	fileValue string
+	fileList  []string
+}
+
+var envFields = append([]envField{
+	{
+		Key:   "flavor",
+		Env:   "GITH_FLAVOR",
+		parse: NormalizeFlavor,
+		get:   func(c *Config) string { return c.Flavor },
+		set:   func(c *Config, value string) { c.Flavor = value },
+	},
+	{
+		Key:   "accent",
+		Env:   "GITH_ACCENT",
+		parse: NormalizeAccent,
+		get:   func(c *Config) string { return c.Accent },
+		set:   func(c *Config, value string) { c.Accent = value },
+	},
+	{
+		Key:   "init",
+		Env:   "GITH_INIT_FETCH",
+		parse: ParseInitBehaviour,
+		get:   func(c *Config) string { return c.InitBehaviour },
+		set:   func(c *Config, value string) { c.InitBehaviour = value },
+	},
+	{
+		Key:   "persistent",
+		Env:   "GITH_PERSISTENT",
+		parse: ParseBool,
+		get:   func(c *Config) string { return strconv.FormatBool(c.Persistent) },
+		set:   func(c *Config, value string) { c.Persistent = value == "true" },
+	},
+	{
+		Key:   "skipConfirm",
+		Env:   "GITH_SKIP_CONFIRM",
+		parse: ParseBool,
+		get:   func(c *Config) string { return strconv.FormatBool(c.SkipConfirm) },
+		set:   func(c *Config, value string) { c.SkipConfirm = value == "true" },
+	},
+	{
+		Key:   "protectedBranches",
+		Env:   "GITH_PROTECTED_BRANCHES",
+		parse: ParseList,
+		get:   func(c *Config) string { return strings.Join(c.ProtectedBranches, ",") },
+		set:   func(c *Config, value string) { c.ProtectedBranches = strings.Split(value, ",") },
+		list:  func(c *Config) *[]string { return &c.ProtectedBranches },
+	},
+	{
+		Key:   "ticketPattern",
+		Env:   "GITH_TICKET_PATTERN",
+		parse: ParseTicketPattern,
+		get:   func(c *Config) string { return c.TicketPattern },
+		set:   func(c *Config, value string) { c.TicketPattern = value },
+	},
+	{
+		Key:   "ticketPlacement",
+		Env:   "GITH_TICKET_PLACEMENT",
+		parse: ParseTicketPlacement,
+		get:   func(c *Config) string { return c.TicketPlacement },
+		set:   func(c *Config, value string) { c.TicketPlacement = value },
+	},
+	{
+		Key:   "branchTemplates",
+		Env:   "GITH_BRANCH_TEMPLATES",
+		parse: ParseBranchTemplates,
+		get:   func(c *Config) string { return strings.Join(c.BranchTemplates, ",") },
+		set:   func(c *Config, value string) { c.BranchTemplates = strings.Split(value, ",") },
+		list:  func(c *Config) *[]string { return &c.BranchTemplates },
+	},
+}, keymapEnvFields()...)
+
+// applyEnvOverrides sets every config key that has a valid value in its
+// GITH_* environment variable. Invalid values are ignored.
+func applyEnvOverrides(c *Config) {
+	for _, field := range envFields {
+		raw, ok := os.LookupEnv(field.Env)
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		value, valid := field.parse(strings.TrimSpace(raw))
+		if !valid {
+			continue
+		}
+
+		if c.overrides == nil {
+			c.overrides = make(map[string]envOverride)
+		}
+		override := envOverride{fileValue: field.get(c)}
+		if field.list != nil {
+			override.fileList = slices.Clone(*field.list(c))
+		}
+		c.overrides[field.Key] = override
+		field.set(c, value)
+	}
+}
+
+// validateEnv reports all GITH_* environment variables with invalid values
+func validateEnv() []Issue {
+	var issues []Issue
+	for _, field := range envFields {
+		raw, ok := os.LookupEnv(field.Env)
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+		if _, valid := field.parse(strings.TrimSpace(raw)); !valid {
+			issues = append(issues, Issue{
+				Path:    "$" + field.Env,
+				Message: fmt.Sprintf("%q is not a valid value for %s, it is ignored", raw, field.Key),
+			})
+		}
+	}
+	return issues
+}
+
+// withoutEnvOverrides returns a copy of the config where every key set from its
+// environment variable is reset to the value from the config file.
+// Keys updated after loading (see MarkUpdated) keep their new value.
+func withoutEnvOverrides(c *Config) *Config {
+	out := *c
+	out.overrides = nil
+	out.updated = nil
+
+	for _, field := range envFields {
+		override, ok := c.overrides[field.Key]
+		if !ok || c.updated[field.Key] {
+			continue
+		}
+		if field.list != nil {
+			*field.list(&out) = override.fileList
+		} else {
+			field.set(&out, override.fileValue)
+		}
+	}
+
+	return &out
+}
+
+// MarkUpdated records keys that were changed after loading, e.g. by `gith config update`,
+// so SaveUpdates writes only them, and SaveConfig keeps their new value even if an environment variable overrides them
+func (c *Config) MarkUpdated(keys ...string) {
+	if c.updated == nil {
+		c.updated = make(map[string]bool)
+	}
+	for _, key := range keys {
+		c.updated[key] = true
+	}
+}
+
+// EnvOverride returns the environment variable that overrides the given key, if any
+func (c *Config) EnvOverride(key string) (string, bool) {
+	if _, ok := c.overrides[key]; !ok {
+		return "", false
+	}
+	for _, field := range envFields {
+		if field.Key == key {
+			return field.Env, true
+		}
+	}
+	return "", false
+}
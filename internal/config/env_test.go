@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithoutEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		update func(c *Config)
+		want   func(c *Config)
+	}{
+		{
+			name: "env value is not saved",
+			env:  map[string]string{"GITH_FLAVOR": "Latte"},
+			want: func(c *Config) { c.Flavor = "Mocha" },
+		},
+		{
+			name: "update to the env value is saved",
+			env:  map[string]string{"GITH_FLAVOR": "Latte"},
+			update: func(c *Config) {
+				c.Flavor = "Latte"
+				c.MarkUpdated("flavor")
+			},
+			want: func(c *Config) { c.Flavor = "Latte" },
+		},
+		{
+			name: "update of another key keeps the file value",
+			env:  map[string]string{"GITH_ACCENT": "Red"},
+			update: func(c *Config) {
+				c.Flavor = "Frappe"
+				c.MarkUpdated("flavor")
+			},
+			want: func(c *Config) { c.Flavor = "Frappe" },
+		},
+		{
+			name: "empty list stays empty",
+			env:  map[string]string{"GITH_PROTECTED_BRANCHES": "main,release"},
+			want: func(c *Config) { c.ProtectedBranches = []string{} },
+		},
+		{
+			name: "keymap is restored",
+			env:  map[string]string{"GITH_KEYMAP_UP": "w"},
+			want: func(c *Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for env, value := range tt.env {
+				t.Setenv(env, value)
+			}
+
+			file := NewDefaultConfig()
+			file.ProtectedBranches = []string{}
+
+			config := NewDefaultConfig()
+			config.ProtectedBranches = []string{}
+			applyEnvOverrides(config)
+			if tt.update != nil {
+				tt.update(config)
+			}
+
+			want := file
+			tt.want(want)
+
+			got := withoutEnvOverrides(config)
+			got.overrides, got.updated = nil, nil
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("withoutEnvOverrides() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
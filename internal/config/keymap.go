@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Keymap holds the keys bound to each action, using bubbletea key names
+// (e.g. "up", "k", "ctrl+h", "pgdown").
+type Keymap struct {
+	Up         []string `json:"up"`
+	Down       []string `json:"down"`
+	PageUp     []string `json:"pageUp"`
+	PageDown   []string `json:"pageDown"`
+	Home       []string `json:"home"`
+	End        []string `json:"end"`
+	Select     []string `json:"select"`
+	Back       []string `json:"back"`
+	Quit       []string `json:"quit"`
+	Filter     []string `json:"filter"`
+	OutputUp   []string `json:"outputUp"`
+	OutputDown []string `json:"outputDown"`
+	Toggle     []string `json:"toggle"`
+	Previous   []string `json:"previous"`
+	NextFile   []string `json:"nextFile"`
+	PrevFile   []string `json:"prevFile"`
+	Parent     []string `json:"parent"`
+}
+
+var DefaultKeymap = Keymap{
+	Up:         []string{"up", "k"},
+	Down:       []string{"down", "j"},
+	PageUp:     []string{"pgup"},
+	PageDown:   []string{"pgdown"},
+	Home:       []string{"home", "g"},
+	End:        []string{"end", "G"},
+	Select:     []string{"enter"},
+	Back:       []string{"ctrl+h", "ctrl+y"},
+	Quit:       []string{"q", "esc", "ctrl+c"},
+	Filter:     []string{"/"},
+	OutputUp:   []string{"shift+up"},
+	OutputDown: []string{"shift+down"},
+	Toggle:     []string{"space"},
+	Previous:   []string{"-"},
+	NextFile:   []string{"right", "l"},
+	PrevFile:   []string{"left", "h"},
+	Parent:     []string{"p"},
+}
+
+// keymapAction describes a single rebindable action of the keymap
+type keymapAction struct {
+	Key  string
+	Env  string
+	keys func(k *Keymap) *[]string
+}
+
+var keymapActions = []keymapAction{
+	{Key: "up", Env: "GITH_KEYMAP_UP", keys: func(k *Keymap) *[]string { return &k.Up }},
+	{Key: "down", Env: "GITH_KEYMAP_DOWN", keys: func(k *Keymap) *[]string { return &k.Down }},
+	{Key: "pageUp", Env: "GITH_KEYMAP_PAGE_UP", keys: func(k *Keymap) *[]string { return &k.PageUp }},
+	{Key: "pageDown", Env: "GITH_KEYMAP_PAGE_DOWN", keys: func(k *Keymap) *[]string { return &k.PageDown }},
+	{Key: "home", Env: "GITH_KEYMAP_HOME", keys: func(k *Keymap) *[]string { return &k.Home }},
+	{Key: "end", Env: "GITH_KEYMAP_END", keys: func(k *Keymap) *[]string { return &k.End }},
+	{Key: "select", Env: "GITH_KEYMAP_SELECT", keys: func(k *Keymap) *[]string { return &k.Select }},
+	{Key: "back", Env: "GITH_KEYMAP_BACK", keys: func(k *Keymap) *[]string { return &k.Back }},
+	{Key: "quit", Env: "GITH_KEYMAP_QUIT", keys: func(k *Keymap) *[]string { return &k.Quit }},
+	{Key: "filter", Env: "GITH_KEYMAP_FILTER", keys: func(k *Keymap) *[]string { return &k.Filter }},
+	{Key: "outputUp", Env: "GITH_KEYMAP_OUTPUT_UP", keys: func(k *Keymap) *[]string { return &k.OutputUp }},
+	{Key: "outputDown", Env: "GITH_KEYMAP_OUTPUT_DOWN", keys: func(k *Keymap) *[]string { return &k.OutputDown }},
+	{Key: "toggle", Env: "GITH_KEYMAP_TOGGLE", keys: func(k *Keymap) *[]string { return &k.Toggle }},
+	{Key: "previous", Env: "GITH_KEYMAP_PREVIOUS", keys: func(k *Keymap) *[]string { return &k.Previous }},
+	{Key: "nextFile", Env: "GITH_KEYMAP_NEXT_FILE", keys: func(k *Keymap) *[]string { return &k.NextFile }},
+	{Key: "prevFile", Env: "GITH_KEYMAP_PREV_FILE", keys: func(k *Keymap) *[]string { return &k.PrevFile }},
+	{Key: "parent", Env: "GITH_KEYMAP_PARENT", keys: func(k *Keymap) *[]string { return &k.Parent }},
+}
+
+func (k Keymap) clone() Keymap {
+	out := k
+	for _, action := range keymapActions {
+		keys := action.keys(&out)
+		*keys = append([]string(nil), *keys...)
+	}
+	return out
+}
+
+// keymapSchemaFields returns the schema of the keymap section
+func keymapSchemaFields() []schemaField {
+	fields := make([]schemaField, 0, len(keymapActions))
+	for _, action := range keymapActions {
+		fields = append(fields, schemaField{Path: "keymap." + action.Key, check: checkKeys})
+	}
+	return fields
+}
+
+// keymapEnvFields returns the env overrides of the keymap, keys are separated by commas
+func keymapEnvFields() []envField {
+	fields := make([]envField, 0, len(keymapActions))
+	for _, action := range keymapActions {
+		fields = append(fields, envField{
+			Key:   "keymap." + action.Key,
+			Env:   action.Env,
+			parse: parseKeys,
+			get:   func(c *Config) string { return strings.Join(*action.keys(&c.Keymap), ",") },
+			set:   func(c *Config, value string) { *action.keys(&c.Keymap) = strings.Split(value, ",") },
+			list:  func(c *Config) *[]string { return action.keys(&c.Keymap) },
+		})
+	}
+	return fields
+}
+
+// parseKeys parses a comma separated list of keys, e.g. "up,k"
+func parseKeys(value string) (string, bool) {
+	var keys []string
+	for key := range strings.SplitSeq(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, ","), len(keys) > 0
+}
+
+func checkKeys(value any) string {
+	list, ok := value.([]any)
+	if !ok {
+		return fmt.Sprintf("expected a list of keys, got %s", describe(value))
+	}
+	if len(list) == 0 {
+		return "at least one key is required"
+	}
+	for _, item := range list {
+		if key, ok := item.(string); !ok || strings.TrimSpace(key) == "" {
+			return fmt.Sprintf("expected key names, got %s", describe(item))
+		}
+	}
+	return ""
+}
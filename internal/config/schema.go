@@ -0,0 +1,302 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// CurrentVersion is the schema version written by SaveConfig.
+// Bump it and add a migration whenever the config format changes.
+const CurrentVersion = 1
+
+// Issue describes an invalid or unknown entry in the config
+type Issue struct {
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// schemaField describes a single config key and how to validate its raw json value
+type schemaField struct {
+	Path  string
+	check func(value any) string
+}
+
+var schemaFields = append([]schemaField{
+	{Path: "version", check: checkVersion},
+	{Path: "flavor", check: checkString(NormalizeFlavor, "not a valid flavor", GetAvailableFlavors())},
+	{Path: "accent", check: checkString(NormalizeAccent, "not a valid accent", GetAvailableAccents())},
+	{Path: "init", check: checkString(ParseInitBehaviour, "not a valid init behaviour", []string{"always", "quick", "never"})},
+	{Path: "persistent", check: checkBool},
+	{Path: "skipConfirm", check: checkBool},
+	{Path: "protectedBranches", check: checkStringList},
+	{Path: "branchTemplates", check: checkBranchTemplates},
+	{Path: "ticketPattern", check: checkTicketPattern},
+	{Path: "ticketPlacement", check: checkString(ParseTicketPlacement, "not a valid ticket placement", GetTicketPlacements())},
+}, keymapSchemaFields()...)
+
+// migrations[n] migrates a raw config from version n to n+1
+var migrations = []func(raw map[string]any){
+	migrateV0,
+}
+
+// migrateV0 normalises the case of all values. Older versions of
+// `gith config update` wrote lowercase values (e.g. "mocha" or "quick").
+func migrateV0(raw map[string]any) {
+	normalizers := map[string]func(string) (string, bool){
+		"flavor": NormalizeFlavor,
+		"accent": NormalizeAccent,
+		"init":   ParseInitBehaviour,
+	}
+	for key, normalize := range normalizers {
+		if value, ok := raw[key].(string); ok {
+			if normalized, valid := normalize(value); valid {
+				raw[key] = normalized
+			}
+		}
+	}
+}
+
+// schemaVersion returns the version of a raw config, 0 for unversioned configs
+func schemaVersion(raw map[string]any) int {
+	if version, ok := raw["version"].(float64); ok {
+		return int(version)
+	}
+	return 0
+}
+
+// migrate upgrades a raw config to CurrentVersion and reports if anything changed
+func migrate(raw map[string]any) bool {
+	version := schemaVersion(raw)
+	if version >= CurrentVersion {
+		return false
+	}
+
+	for v := max(version, 0); v < CurrentVersion; v++ {
+		migrations[v](raw)
+	}
+	raw["version"] = float64(CurrentVersion)
+	return true
+}
+
+// validate reports every invalid or unknown key of a raw (migrated) config
+func validate(raw map[string]any) []Issue {
+	var issues []Issue
+	known := make(map[string]bool)
+
+	for _, field := range schemaFields {
+		known[field.Path] = true
+
+		value, ok := lookup(raw, field.Path)
+		if !ok {
+			continue
+		}
+		if msg := field.check(value); msg != "" {
+			issues = append(issues, Issue{Path: field.Path, Message: msg})
+		}
+	}
+
+	for _, path := range keyPaths(raw, "") {
+		switch {
+		case isSection(path):
+			// keyPaths only stops at a section if it is not an object
+			value, _ := lookup(raw, path)
+			issues = append(issues, Issue{Path: path, Message: fmt.Sprintf("expected an object, got %s", describe(value))})
+		case !known[path]:
+			issues = append(issues, Issue{Path: path, Message: "unknown key"})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues
+}
+
+// dropInvalid removes all keys with issues from the raw config, so the defaults are used for them
+func dropInvalid(raw map[string]any, issues []Issue) {
+	for _, issue := range issues {
+		parts := strings.Split(issue.Path, ".")
+		section := raw
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := section[part].(map[string]any)
+			if !ok {
+				section = nil
+				break
+			}
+			section = next
+		}
+		if section != nil {
+			delete(section, parts[len(parts)-1])
+		}
+	}
+}
+
+// lookup returns the value at a dotted path like "keymap.quit"
+func lookup(raw map[string]any, path string) (any, bool) {
+	var current any = raw
+	for part := range strings.SplitSeq(path, ".") {
+		section, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = section[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// store sets the value at a dotted path like "keymap.quit", creating missing sections
+func store(raw map[string]any, path string, value any) {
+	parts := strings.Split(path, ".")
+	section := raw
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := section[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			section[part] = next
+		}
+		section = next
+	}
+	section[parts[len(parts)-1]] = value
+}
+
+// keyPaths returns the dotted paths of all leaf keys
+func keyPaths(raw map[string]any, prefix string) []string {
+	var paths []string
+	for key, value := range raw {
+		path := prefix + key
+		if section, ok := value.(map[string]any); ok && isSection(path) {
+			paths = append(paths, keyPaths(section, path+".")...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// isSection returns true if the path is a parent of any known field
+func isSection(path string) bool {
+	for _, field := range schemaFields {
+		if strings.HasPrefix(field.Path, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func checkVersion(value any) string {
+	version, ok := value.(float64)
+	if !ok || version != float64(int(version)) || version < 0 {
+		return fmt.Sprintf("expected a whole number, got %s", describe(value))
+	}
+	if int(version) > CurrentVersion {
+		return fmt.Sprintf("config was written by a newer gith (schema version %d, supported up to %d)", int(version), CurrentVersion)
+	}
+	return ""
+}
+
+func checkBool(value any) string {
+	if _, ok := value.(bool); !ok {
+		return fmt.Sprintf("expected true or false, got %s", describe(value))
+	}
+	return ""
+}
+
+func checkStringList(value any) string {
+	list, ok := value.([]any)
+	if !ok {
+		return fmt.Sprintf("expected a list of strings, got %s", describe(value))
+	}
+	for _, item := range list {
+		if str, ok := item.(string); !ok || strings.TrimSpace(str) == "" {
+			return fmt.Sprintf("expected names, got %s", describe(item))
+		}
+	}
+	return ""
+}
+
+func checkBranchTemplates(value any) string {
+	list, ok := value.([]any)
+	if !ok {
+		return fmt.Sprintf("expected a list of strings, got %s", describe(value))
+	}
+	for _, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			return fmt.Sprintf("expected templates, got %s", describe(item))
+		}
+		if _, ok := TemplateFields(str); !ok {
+			return fmt.Sprintf("%q is not a valid template, use fields like {type}/{ticket}-{slug}", str)
+		}
+	}
+	return ""
+}
+
+func checkTicketPattern(value any) string {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Sprintf("expected a string, got %s", describe(value))
+	}
+	if _, err := regexp.Compile(str); err != nil || strings.TrimSpace(str) == "" {
+		return fmt.Sprintf("%q is not a valid regular expression", str)
+	}
+	return ""
+}
+
+func checkString(parse func(string) (string, bool), msg string, valid []string) func(any) string {
+	return func(value any) string {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string, got %s", describe(value))
+		}
+		if _, ok := parse(str); !ok {
+			return fmt.Sprintf("%q is %s (valid: %s)", str, msg, strings.Join(valid, ", "))
+		}
+		return ""
+	}
+}
+
+// describe returns a short, human readable description of a raw json value
+func describe(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case float64:
+		return fmt.Sprintf("%v", v)
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// NormalizeFlavor returns the canonical spelling of a flavor (e.g. "mocha" -> "Mocha")
+func NormalizeFlavor(flavor string) (string, bool) {
+	return normalize(flavor, GetAvailableFlavors())
+}
+
+// NormalizeAccent returns the canonical spelling of an accent (e.g. "blue" -> "Blue")
+func NormalizeAccent(accent string) (string, bool) {
+	return normalize(accent, GetAvailableAccents())
+}
+
+func normalize(value string, valid []string) (string, bool) {
+	value = strings.TrimSpace(value)
+	index := slices.IndexFunc(valid, func(v string) bool { return strings.EqualFold(v, value) })
+	if index == -1 {
+		return "", false
+	}
+	return valid[index], true
+}
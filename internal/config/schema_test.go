@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMigrateAndResolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		wantMigrated bool
+		// the raw config after the migration, as saved to the file
+		wantRaw    string
+		wantIssues []string
+		wantFlavor string
+		wantInit   string
+	}{
+		{
+			name:         "unversioned lowercase values are normalised",
+			file:         `{"flavor": "latte", "accent": "blue", "init": "quick"}`,
+			wantMigrated: true,
+			wantRaw:      `{"version": 1, "flavor": "Latte", "accent": "Blue", "init": "Do not fetch for Quick Selects"}`,
+			wantFlavor:   "Latte",
+			wantInit:     "Do not fetch for Quick Selects",
+		},
+		{
+			name:         "invalid and unknown keys survive the migration",
+			file:         `{"flavor": "purple", "init": "never", "colour": "red", "keymap": {"up": "k", "jump": ["j"]}}`,
+			wantMigrated: true,
+			wantRaw:      `{"version": 1, "flavor": "purple", "init": "Never fetch", "colour": "red", "keymap": {"up": "k", "jump": ["j"]}}`,
+			wantIssues:   []string{"colour", "flavor", "keymap.jump", "keymap.up"},
+			wantFlavor:   "Mocha",
+			wantInit:     "Never fetch",
+		},
+		{
+			name:         "current version is not migrated",
+			file:         `{"version": 1, "flavor": "Frappe", "skipConfirm": "yes"}`,
+			wantMigrated: false,
+			wantRaw:      `{"version": 1, "flavor": "Frappe", "skipConfirm": "yes"}`,
+			wantIssues:   []string{"skipConfirm"},
+			wantFlavor:   "Frappe",
+			wantInit:     DefaultConfig.InitBehaviour,
+		},
+		{
+			name:         "newer version is reported, not downgraded",
+			file:         `{"version": 7, "flavor": "Macchiato"}`,
+			wantMigrated: false,
+			wantRaw:      `{"version": 7, "flavor": "Macchiato"}`,
+			wantIssues:   []string{"version"},
+			wantFlavor:   "Macchiato",
+			wantInit:     DefaultConfig.InitBehaviour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := parseRaw([]byte(tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if migrated := migrate(raw); migrated != tt.wantMigrated {
+				t.Errorf("migrate() = %v, want %v", migrated, tt.wantMigrated)
+			}
+
+			config, err := resolve(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantRaw, err := parseRaw([]byte(tt.wantRaw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(raw, wantRaw) {
+				got, _ := json.Marshal(raw)
+				t.Errorf("raw config = %s, want %s", got, tt.wantRaw)
+			}
+
+			var issues []string
+			for _, issue := range config.Issues() {
+				issues = append(issues, issue.Path)
+			}
+			if !reflect.DeepEqual(issues, tt.wantIssues) {
+				t.Errorf("issues = %v, want %v", issues, tt.wantIssues)
+			}
+
+			if config.Flavor != tt.wantFlavor {
+				t.Errorf("Flavor = %q, want %q", config.Flavor, tt.wantFlavor)
+			}
+			if config.InitBehaviour != tt.wantInit {
+				t.Errorf("InitBehaviour = %q, want %q", config.InitBehaviour, tt.wantInit)
+			}
+		})
+	}
+}
+
+func TestApplyUpdates(t *testing.T) {
+	raw, err := parseRaw([]byte(`{"version": 1, "flavor": "purple", "accent": "Blue", "colour": "red", "keymap": {"jump": ["j"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := resolve(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Accent = "Red"
+	config.Keymap.Quit = []string{"x"}
+	config.MarkUpdated("accent", "keymap.quit")
+
+	if err := applyUpdates(raw, config); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := parseRaw([]byte(`{"version": 1, "flavor": "purple", "accent": "Red", "colour": "red", "keymap": {"jump": ["j"], "quit": ["x"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(raw, want) {
+		got, _ := json.Marshal(raw)
+		t.Errorf("raw config = %s", got)
+	}
+}
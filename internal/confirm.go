@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxConfirmItems limits how many commits or branches are listed in a confirmation
+const maxConfirmItems = 8
+
+// alsoDeleteRemote is the answer to delete the remote branches together with the local ones
+const alsoDeleteRemote = "Yes, and delete the remote branch too"
+
+// askConfirmation switches to the confirmation step.
+// action is dispatched by HandleConfirmSelection once the user answered anything but "No",
+// extra answers are offered between "Yes" and "No".
+func (m *Model) askConfirmation(title string, lines []string, action string, extra ...string) {
+	options := append([]string{"Yes"}, extra...)
+	m.ConfirmModel = ConfirmModel{
+		Title:   title,
+		Lines:   lines,
+		Options: append(options, "No"),
+		Action:  action,
+	}
+	m.Selected = len(m.ConfirmModel.Options) - 1 // default to "No"
+	m.CurrentStep = StepConfirm
+	m.Level = 4
+}
+
+// confirm asks for confirmation before running action,
+// unless confirmations are skipped via config or --yes
+func (m *Model) confirm(title string, lines []string, action string, extra ...string) (*Model, tea.Cmd) {
+	if m.SkipConfirm {
+		return m.executeConfirmed(action)
+	}
+	m.askConfirmation(title, lines, action, extra...)
+	return m, nil
+}
+
+func (m Model) HandleConfirmSelection() (tea.Model, tea.Cmd) {
+	m.ConfirmModel.Answer = m.ConfirmModel.Options[m.Selected]
+
+	if m.ConfirmModel.Answer == "No" {
+		if m.ConfirmModel.Action == "rename-remote-branch" {
+			// the local rename already happened
+			m.Success = fmt.Sprintf("Renamed Branch to '%s', the remote branch is unchanged", strings.TrimSpace(m.BranchModel.Input))
+			return m, m.finish()
+		}
+		m.Err = "Cancelled"
+		return m, m.finish()
+	}
+
+	return m.executeConfirmed(m.ConfirmModel.Action)
+}
+
+// executeConfirmed runs a confirmed action
+func (m *Model) executeConfirmed(action string) (*Model, tea.Cmd) {
+	switch action {
+	case "delete-branch":
+		m.BranchModel.DeleteRemote = m.ConfirmModel.Answer == alsoDeleteRemote
+		return m.ExecuteBranchAction()
+	case "force-delete-branch":
+		m.BranchModel.DeleteRemote = m.BranchModel.DeleteRemote || m.ConfirmModel.Answer == alsoDeleteRemote
+		return m.ExecuteForceBranchDelete()
+	case "delete-branches":
+		m.BranchModel.DeleteRemote = m.ConfirmModel.Answer == alsoDeleteRemote
+		return m.ExecuteBatchBranchDelete()
+	case "remove-tag":
+		return m.ExecuteTagAction()
+	case "remove-tags":
+		return m.ExecuteBatchTagRemove()
+	case "remove-remote":
+		return m.ExecuteRemoteAction()
+	case "rename-remote-branch":
+		return m.ExecuteRemoteBranchRename()
+	case "undo-commit":
+		return m.ExecuteUndoCommit()
+	}
+	return m, m.finish()
+}
+
+// branchDeleteDetails describes what deleting a local branch does
+func branchDeleteDetails(branch string) []string {
+	lines := []string{"Deletes the local branch " + branch}
+
+	if last, err := git.GetLastCommit("refs/heads/" + branch); err == nil {
+		lines = append(lines, "\\ctLast commit: "+last)
+	}
+
+	if remote, remoteBranch := remoteBranchOf(branch); remote != "" {
+		lines = append(lines, "\\ctRemote branch: "+remote+"/"+remoteBranch+" (only deleted if chosen)")
+	}
+
+	commits, err := git.GetUniqueCommits(branch)
+	if err == nil && commits != "" {
+		lines = append(lines, itemListDetails(strings.Split(commits, "\n"), "\\cr",
+			"commit is only on this branch and will be lost",
+			"commits are only on this branch and will be lost")...)
+	}
+
+	return lines
+}
+
+// forceDeleteDetails lists the commits that are not merged into HEAD
+// and which of them would be lost by force deleting the branch
+func forceDeleteDetails(branch string) []string {
+	unmerged, err := git.GetUnmergedCommits(branch)
+	if err != nil || unmerged == "" {
+		return []string{"Deletes the branch " + branch + " with git branch -D"}
+	}
+
+	lines := itemListDetails(strings.Split(unmerged, "\n"), "\\cp",
+		"commit is not merged into the current branch",
+		"commits are not merged into the current branch")
+
+	unique, err := git.GetUniqueCommits(branch)
+	if err == nil && unique == "" {
+		lines = append(lines, "\\cgAll of them are still reachable from other branches, tags or remotes")
+	} else if err == nil {
+		count := len(strings.Split(unique, "\n"))
+		if count == 1 {
+			lines = append(lines, "\\cr1 of them is only on this branch and will be lost")
+		} else {
+			lines = append(lines, fmt.Sprintf("\\cr%d of them are only on this branch and will be lost", count))
+		}
+	}
+
+	return lines
+}
+
+// batchBranchDeleteDetails lists the branches to delete, marking the ones with commits that would be lost
+func batchBranchDeleteDetails(branches []string) []string {
+	var lines []string
+	for _, option := range branches {
+		branch := branchName(option)
+		line := branch
+		if remote, remoteBranch := remoteBranchOf(branch); remote != "" {
+			line += " (remote: " + remote + "/" + remoteBranch + ")"
+		}
+
+		commits, err := git.GetUniqueCommits(branch)
+		if err == nil && commits != "" {
+			line = fmt.Sprintf("\\cr%s, %d commits only on this branch", line, len(strings.Split(commits, "\n")))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// mergedBranchDeleteDetails lists merged branches to delete, see PrepareMergedBranchSelection
+func mergedBranchDeleteDetails(branches []string, base string) []string {
+	lines := []string{"\\ctAll changes of these branches are part of " + base + ":"}
+	for _, option := range branches {
+		line := "  " + option
+		if remote, remoteBranch := remoteBranchOf(branchName(option)); remote != "" {
+			line += ", remote: " + remote + "/" + remoteBranch
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "Deleted with git branch -D, as they may not be merged into the current branch")
+	return lines
+}
+
+// tagRemoveDetails describes what removing a local tag does
+func tagRemoveDetails(tag string) []string {
+	lines := []string{"Deletes the local tag " + tag}
+	if commit, err := git.GetLastCommit("refs/tags/" + tag); err == nil {
+		lines = append(lines, "\\ctPoints to: "+commit)
+	}
+	lines = append(lines, "Tags already pushed to a remote are kept there")
+	return lines
+}
+
+// remoteRemoveDetails describes what removing a remote does
+func remoteRemoveDetails(remote string) []string {
+	lines := []string{"Removes the remote " + remote}
+	if url, errOut := git.GetRemoteUrl(remote); errOut == "" {
+		lines = append(lines, "\\ctURL: "+url)
+	}
+
+	if branches, errOut := git.GetRemoteBranches(remote); errOut == "" && branches != "" {
+		lines = append(lines, itemListDetails(strings.Split(branches, "\n"), "\\cp", "remote-tracking branch is deleted", "remote-tracking branches are deleted")...)
+	}
+
+	if branches, errOut := git.GetBranchesTracking(remote); errOut == "" && branches != "" {
+		lines = append(lines, itemListDetails(strings.Split(branches, "\n"), "\\cp", "local branch loses its upstream", "local branches lose their upstream")...)
+	}
+
+	return lines
+}
+
+// itemListDetails lists items below a colored headline (e.g. "\\cr3 commits are lost:"),
+// shortened to maxConfirmItems
+func itemListDetails(items []string, color string, singular string, plural string) []string {
+	headline := fmt.Sprintf("%s%d %s:", color, len(items), plural)
+	if len(items) == 1 {
+		headline = color + "1 " + singular + ":"
+	}
+
+	lines := []string{headline}
+	for i, item := range items {
+		if i == maxConfirmItems {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(items)-maxConfirmItems))
+			break
+		}
+		lines = append(lines, "  "+item)
+	}
+	return lines
+}
+
+// undoCommitDetails describes what undoing the last commit does
+func undoCommitDetails() []string {
+	lines := []string{}
+	if last, err := git.GetLastCommit("HEAD"); err == nil {
+		lines = append(lines, "Undoes the commit "+last)
+	}
+	lines = append(lines, "Its changes stay staged (git reset --soft HEAD~1)")
+
+	if pushed, err := git.IsHeadPushed(); err == nil && pushed {
+		lines = append(lines, "\\crThe commit is already pushed, undoing it rewrites published history")
+	}
+	return lines
+}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/a3chron/gith/internal/git"
+	"github.com/a3chron/gith/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openDiff shows a diff in the diff pane one level below the current one,
+// it returns false if the diff is empty
+func (m *Model) openDiff(diff string) bool {
+	files := git.ParseDiff(diff)
+	if len(files) == 0 {
+		return false
+	}
+
+	m.DiffModel = DiffModel{Files: files}
+	m.CurrentStep = StepDiff
+	m.Level++
+	return true
+}
+
+// showDiffFile jumps to the file with the given path, e.g. the file selected in a list of changed files
+func (m *Model) showDiffFile(path string) {
+	for i, file := range m.DiffModel.Files {
+		if file.Path == path {
+			m.DiffModel.File = i
+			return
+		}
+	}
+}
+
+// handleDiffKey scrolls the lines of the current file or switches to the next or previous file
+func (m *Model) handleDiffKey(msg tea.KeyMsg) {
+	lastScroll := 0
+	if height := m.diffHeight(); height > 0 {
+		lastScroll = max(len(m.DiffModel.Files[m.DiffModel.File].Lines)-height, 0)
+	}
+
+	switch {
+	case key.Matches(msg, m.Keys.Up):
+		m.DiffModel.Scroll = max(m.DiffModel.Scroll-1, 0)
+	case key.Matches(msg, m.Keys.Down):
+		m.DiffModel.Scroll = min(m.DiffModel.Scroll+1, lastScroll)
+	case key.Matches(msg, m.Keys.PageUp):
+		m.DiffModel.Scroll = max(m.DiffModel.Scroll-m.pageSize(), 0)
+	case key.Matches(msg, m.Keys.PageDown):
+		m.DiffModel.Scroll = min(m.DiffModel.Scroll+m.pageSize(), lastScroll)
+	case key.Matches(msg, m.Keys.Home):
+		m.DiffModel.Scroll = 0
+	case key.Matches(msg, m.Keys.End):
+		m.DiffModel.Scroll = lastScroll
+	case key.Matches(msg, m.Keys.NextFile):
+		if m.DiffModel.File < len(m.DiffModel.Files)-1 {
+			m.DiffModel.File++
+			m.DiffModel.Scroll = 0
+		}
+	case key.Matches(msg, m.Keys.PrevFile):
+		if m.DiffModel.File > 0 {
+			m.DiffModel.File--
+			m.DiffModel.Scroll = 0
+		}
+	}
+}
+
+// diffHeight returns how many lines of a file fit in the diff pane, 0 shows all lines
+func (m Model) diffHeight() int {
+	return m.listHeight()
+}
+
+// renderDiffLines colors the lines of a file diff. Changed words are highlighted
+// when a block of removed lines is replaced by a block of as many added lines.
+func renderDiffLines(lines []git.DiffLine, width int) []string {
+	// a removed or added line of a hunk
+	changed := func(i int, marker string) bool {
+		return i < len(lines) && lines[i].Hunk && strings.HasPrefix(lines[i].Text, marker)
+	}
+	text := func(i int) string {
+		return strings.ReplaceAll(lines[i].Text, "\t", "    ")
+	}
+
+	rendered := make([]string, len(lines))
+	for i := 0; i < len(lines); i++ {
+		rendered[i] = renderDiffLine(text(i), lines[i].Hunk)
+
+		if !changed(i, "-") {
+			continue
+		}
+
+		// a block of removed lines directly followed by added lines
+		removed := i
+		for changed(removed, "-") {
+			removed++
+		}
+		added := removed
+		for changed(added, "+") {
+			added++
+		}
+		if removed-i != added-removed {
+			continue
+		}
+
+		for j := range removed - i {
+			rendered[i+j], rendered[removed+j] = highlightChangedWords(text(i+j), text(removed+j))
+		}
+		i = added - 1
+	}
+
+	if width > 0 {
+		for i, line := range rendered {
+			rendered[i] = lipgloss.NewStyle().MaxWidth(width).Render(line)
+		}
+	}
+	return rendered
+}
+
+// renderDiffLine colors a single line of a diff by its kind, hunk is false for the header lines
+func renderDiffLine(line string, hunk bool) string {
+	switch {
+	case !hunk:
+		// e.g. "--- a/<path>", "index 1a2b3c4..5d6e7f8" or "new file mode 100644"
+		return ui.DimStyle.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return ui.AccentStyle.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return ui.GreenStyle.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return ui.RedStyle.Render(line)
+	case strings.HasPrefix(line, " "), line == "":
+		return ui.NormalStyle.Render(line)
+	default:
+		// e.g. "\ No newline at end of file"
+		return ui.DimStyle.Render(line)
+	}
+}
+
+// highlightChangedWords renders a removed and an added line with the words between
+// their common start and end highlighted, like git's contrib/diff-highlight
+func highlightChangedWords(oldLine string, newLine string) (string, string) {
+	oldWords, newWords := splitWords(oldLine[1:]), splitWords(newLine[1:])
+
+	prefix := 0
+	for prefix < len(oldWords) && prefix < len(newWords) && oldWords[prefix] == newWords[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldWords)-prefix && suffix < len(newWords)-prefix &&
+		oldWords[len(oldWords)-1-suffix] == newWords[len(newWords)-1-suffix] {
+		suffix++
+	}
+
+	// a line that changed completely reads better without highlight
+	if prefix == 0 && suffix == 0 {
+		return renderDiffLine(oldLine, true), renderDiffLine(newLine, true)
+	}
+
+	render := func(marker string, words []string, style lipgloss.Style, highlight lipgloss.Style) string {
+		changed := strings.Join(words[prefix:len(words)-suffix], "")
+		return style.Render(marker+strings.Join(words[:prefix], "")) +
+			highlight.Render(changed) +
+			style.Render(strings.Join(words[len(words)-suffix:], ""))
+	}
+	return render("-", oldWords, ui.RedStyle, ui.RedHighlightStyle),
+		render("+", newWords, ui.GreenStyle, ui.GreenHighlightStyle)
+}
+
+// splitWords splits a line into words, runs of spaces and single other characters
+func splitWords(line string) []string {
+	var words []string
+	start := 0
+	runes := []rune(line)
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && wordClass(runes[i]) == wordClass(runes[i-1]) && wordClass(runes[i]) != 0 {
+			continue
+		}
+		words = append(words, string(runes[start:i]))
+		start = i
+	}
+	return words
+}
+
+// wordClass groups the characters of a word, 0 for characters that are a word on their own
+func wordClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return 1
+	case unicode.IsSpace(r):
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	"github.com/a3chron/gith/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typePath is the File History option to type a path, e.g. of a deleted file
+const typePath = "Type a path"
+
+// PrepareFileHistoryPathSelection lists the tracked files, after the option to type a path
+func (m *Model) PrepareFileHistoryPathSelection() (*Model, tea.Cmd) {
+	files, err := git.GetTrackedFiles()
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+
+	m.FileHistoryModel.Paths = append([]string{typePath}, files...)
+	m.Selected = 0
+	m.CurrentStep = StepFileHistoryPath
+	m.Level = 3
+	return m, nil
+}
+
+func (m Model) HandleFileHistoryPathSelection() (tea.Model, tea.Cmd) {
+	option := m.FileHistoryModel.Paths[m.Selected]
+	if option != typePath {
+		m.FileHistoryModel.Path = option
+		return m.PrepareFileHistory()
+	}
+
+	m.CurrentStep = StepFileHistoryInput
+	m.startInput("", "e.g. internal/app.go", validateHistoryPath)
+
+	// complete the tracked files with tab
+	m.Input.Field.ShowSuggestions = true
+	m.Input.Field.CompletionStyle = ui.DimStyle
+	m.Input.Field.SetSuggestions(m.FileHistoryModel.Paths[1:])
+	return m, nil
+}
+
+func (m *Model) HandleFileHistoryInputSubmit() (*Model, tea.Cmd) {
+	m.FileHistoryModel.Path = strings.TrimSpace(m.FileHistoryModel.PathInput)
+	return m.PrepareFileHistory()
+}
+
+// validateHistoryPath checks that a commit changed the path, it does not have to exist anymore
+func validateHistoryPath(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("Path cannot be empty")
+	}
+	if !git.HasHistory(value) {
+		return fmt.Errorf("No commit changed '%s'", value)
+	}
+	return nil
+}
+
+// PrepareFileHistory lists the commits that changed the file, following renames
+func (m *Model) PrepareFileHistory() (*Model, tea.Cmd) {
+	commits, err := git.GetFileHistory(m.FileHistoryModel.Path)
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if len(commits) == 0 {
+		m.Err = fmt.Sprintf("No commit changed '%s'", m.FileHistoryModel.Path)
+		return m, m.finish()
+	}
+
+	// git log shows the paths from the top of the repository
+	fullPath := path.Clean(git.GetPathPrefix() + m.FileHistoryModel.Path)
+
+	m.FileHistoryModel.Commits = commits
+	m.FileHistoryModel.Options = nil
+	for _, commit := range commits {
+		option := commit.Sha + " " + commit.Subject
+		if n := len(commit.Paths); n > 0 && commit.Paths[n-1] != fullPath {
+			// the file had another name back then
+			option += " as " + commit.Paths[n-1]
+		}
+		m.FileHistoryModel.Options = append(m.FileHistoryModel.Options, option)
+	}
+
+	m.Selected = 0
+	m.CurrentStep = StepFileHistory
+	m.Level = 4
+	return m, nil
+}
+
+// HandleFileHistorySelection shows the diff of the file in the selected commit
+func (m Model) HandleFileHistorySelection() (tea.Model, tea.Cmd) {
+	commit := m.FileHistoryModel.Commits[m.Selected]
+	m.FileHistoryModel.SelectedCommit = m.FileHistoryModel.Options[m.Selected]
+
+	paths := []string{m.FileHistoryModel.Path}
+	if len(commit.Paths) > 0 {
+		paths = nil
+		for _, commitPath := range commit.Paths {
+			paths = append(paths, ":(top)"+commitPath)
+		}
+	}
+	diff, err := git.GetCommitDiff(commit.Sha, paths...)
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+	if !m.openDiff(diff) {
+		m.Success = fmt.Sprintf("Commit %s did not change the content of %s", commit.Sha, m.FileHistoryModel.Path)
+		return m, m.finish()
+	}
+	return m, nil
+}
@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FilterModel holds the incremental fuzzy filter of the current selection list
+type FilterModel struct {
+	Active bool
+	Query  string
+}
+
+// filterMatch is an option that matches the filter query.
+// Index is the position in the unfiltered options, Positions the matched runes.
+type filterMatch struct {
+	Index     int
+	Positions []int
+	score     int
+}
+
+// fuzzyMatch checks if all runes of the query appear in order in the option (case insensitive).
+// Consecutive matches and matches at the start of words score higher.
+func fuzzyMatch(query string, option string) (filterMatch, bool) {
+	match := filterMatch{}
+	queryRunes := []rune(strings.ToLower(query))
+	if len(queryRunes) == 0 {
+		return match, true
+	}
+
+	optionRunes := []rune(option)
+	q := 0
+	prevMatched := false
+	for i, r := range optionRunes {
+		if q == len(queryRunes) {
+			break
+		}
+		if unicode.ToLower(r) != queryRunes[q] {
+			prevMatched = false
+			continue
+		}
+
+		match.Positions = append(match.Positions, i)
+		match.score++
+		if prevMatched {
+			match.score += 2
+		}
+		if i == 0 || strings.ContainsRune("/-_. ", optionRunes[i-1]) {
+			match.score += 3
+		}
+		prevMatched = true
+		q++
+	}
+
+	if q < len(queryRunes) {
+		return match, false
+	}
+
+	// prefer shorter options for equal matches
+	match.score -= len(optionRunes) / 10
+	return match, true
+}
+
+// filterOptions returns all options matching the query, best matches first
+func filterOptions(query string, options []string) []filterMatch {
+	var matches []filterMatch
+	for i, option := range options {
+		if match, ok := fuzzyMatch(query, option); ok {
+			match.Index = i
+			matches = append(matches, match)
+		}
+	}
+
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+	return matches
+}
+
+// visibleOptions returns the options of the current step that are shown,
+// i.e. all options or only the ones matching the active filter
+func (m Model) visibleOptions() []filterMatch {
+	options := m.getCurrentOptions()
+	if !m.Filter.Active {
+		matches := make([]filterMatch, len(options))
+		for i := range options {
+			matches[i] = filterMatch{Index: i}
+		}
+		return matches
+	}
+	return filterOptions(m.Filter.Query, options)
+}
+
+// selectedOptionIndex maps the selection to the index in the unfiltered options,
+// -1 if nothing matches the filter
+func (m Model) selectedOptionIndex() int {
+	if !m.Filter.Active {
+		return m.Selected
+	}
+	matches := m.visibleOptions()
+	if m.Selected < 0 || m.Selected >= len(matches) {
+		return -1
+	}
+	return matches[m.Selected].Index
+}
+
+// isFilterableStep returns true for steps showing a selection list
+func (m Model) isFilterableStep() bool {
+	switch m.CurrentStep {
+	case StepLoad, StepConfirm:
+		return false
+	}
+	return !isInputStep(m.CurrentStep) && len(m.getCurrentOptions()) > 0
+}
+
+// handleFilterKey handles keys while the filter is active.
+// Text keys edit the query, the select key selects from the filtered options.
+func (m Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		m.Err = "User quit"
+		return m, tea.Quit
+
+	case matchesInInput(msg, m.Keys.Quit), matchesInInput(msg, m.Keys.Back):
+		// leave the filter, but keep the selected option selected
+		m.Selected = max(m.selectedOptionIndex(), 0)
+		m.Filter = FilterModel{}
+
+	case matchesInInput(msg, m.Keys.Select):
+		index := m.selectedOptionIndex()
+		if index == -1 {
+			return m, nil
+		}
+		m.Selected = index
+		m.Filter = FilterModel{}
+		return m.withHistory(m.handleEnterKey())
+
+	case msg.Type == tea.KeyBackspace:
+		if m.Filter.Query != "" {
+			_, size := utf8.DecodeLastRuneInString(m.Filter.Query)
+			m.Filter.Query = m.Filter.Query[:len(m.Filter.Query)-size]
+			m.Selected = 0
+		}
+
+	case isTextKey(msg):
+		m.Filter.Query += string(msg.Runes)
+		m.Selected = 0
+
+	case key.Matches(msg, m.Keys.Up, m.Keys.Down, m.Keys.PageUp, m.Keys.PageDown, m.Keys.Home, m.Keys.End):
+		m.handleNavigation(msg)
+	}
+
+	return m, nil
+}
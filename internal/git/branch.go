@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
 )
 
@@ -39,7 +40,8 @@ func GetBranches() ([]string, error) {
 			if strings.Contains(branch, "HEAD ->") {
 				continue
 			}
-			if branch != "" && !seen[branch] {
+			// the current branch can also be on the remote
+			if branch != "" && branch != currentBranch && !seen[branch] {
 				branches = append(branches, branch)
 				seen[branch] = true
 			}
@@ -63,8 +65,18 @@ func GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-func CreateBranch(branchName string) (string, error) {
-	out, err := exec.Command("git", "checkout", "-b", branchName).CombinedOutput()
+// CreateBranch creates a branch starting at base (HEAD if empty) and switches to it if switchTo is set.
+// The new branch does not track base, even if base is a remote branch.
+func CreateBranch(branchName string, base string, switchTo bool) (string, error) {
+	args := []string{"branch", "--no-track", branchName}
+	if switchTo {
+		args = []string{"switch", "--no-track", "-c", branchName}
+	}
+	if base != "" {
+		args = append(args, base)
+	}
+
+	out, err := exec.Command("git", args...).CombinedOutput()
 	if err != nil {
 		return string(out), err
 	} else {
@@ -99,8 +111,331 @@ func DeleteBranch(selectedBranch string) (string, error) {
 	out, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return string(out) + "\n" + "\\cpTo force delete use: \ngit branch -D" + selectedBranch, err
+		return string(out), err
 	} else {
 		return string(out), nil
 	}
 }
+
+// ForceDeleteBranch deletes a local branch even if it is not fully merged
+func ForceDeleteBranch(selectedBranch string) (string, error) {
+	out, err := exec.Command("git", "branch", "-D", selectedBranch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// IsNotFullyMerged checks if git branch -d refuses to delete a branch because it is not fully merged,
+// i.e. not merged into its upstream, or into HEAD if the upstream is unset or gone
+func IsNotFullyMerged(branch string) bool {
+	reference := "HEAD"
+	out, err := exec.Command("git", "for-each-ref", "--format=%(upstream)", "refs/heads/"+branch).Output()
+	if upstream := strings.TrimSpace(string(out)); err == nil && upstream != "" &&
+		exec.Command("git", "rev-parse", "--verify", "--quiet", upstream).Run() == nil {
+		reference = upstream
+	}
+
+	// exits with 1 if the branch is not an ancestor, other errors say nothing about the merge
+	err = exec.Command("git", "merge-base", "--is-ancestor", "refs/heads/"+branch, reference).Run()
+	exitErr, ok := err.(*exec.ExitError)
+	return ok && exitErr.ExitCode() == 1
+}
+
+// GetUnmergedCommits returns the commits (oneline) of the given branch that are not merged into HEAD
+func GetUnmergedCommits(branch string) (string, error) {
+	out, err := exec.Command("git", "log", "--format=%h %s (%cr)", "HEAD..refs/heads/"+branch).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get unmerged commits of %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetUniqueCommits returns the commits (oneline) that are only reachable from the given branch,
+// i.e. the commits that are lost when it is deleted
+func GetUniqueCommits(branch string) (string, error) {
+	out, err := exec.Command("git", "log", "--format=%h %s", "refs/heads/"+branch,
+		"--not", "--exclude="+branch, "--branches", "--tags", "--remotes").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get commits of %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetUpstream returns the upstream of the given branch (e.g. origin/main), empty if it has none
+func GetUpstream(branch string) (string, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(upstream:short)", "refs/heads/"+branch).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get upstream of %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetLastCommit returns the latest commit of a ref as "<short sha> <subject> (<relative date>)"
+func GetLastCommit(ref string) (string, error) {
+	out, err := exec.Command("git", "log", "-1", "--format=%h %s (%cr)", ref, "--").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get last commit of %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetUpstreamRef returns the remote and the branch on the remote that the given branch tracks,
+// both empty if it has no upstream
+func GetUpstreamRef(branch string) (string, string, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(upstream:remotename) %(upstream:remoteref)", "refs/heads/"+branch).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get upstream of %s: %w", branch, err)
+	}
+
+	remote, ref, found := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if !found || remote == "" || ref == "" {
+		return "", "", nil
+	}
+	return remote, strings.TrimPrefix(ref, "refs/heads/"), nil
+}
+
+// DeleteRemoteBranch deletes a branch on a remote with `git push <remote> --delete <branch>`
+func DeleteRemoteBranch(remote string, branch string) (string, error) {
+	out, err := exec.Command("git", "push", remote, "--delete", branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// GetStaleBranches fetches all remotes with --prune and returns the local branches
+// whose upstream is gone, one per line as "<branch> <relative date of the last commit>"
+func GetStaleBranches() (string, error) {
+	if out, err := exec.Command("git", "fetch", "--all", "--prune").CombinedOutput(); err != nil {
+		return string(out), fmt.Errorf("failed to fetch remotes: %w", err)
+	}
+
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname:short)|%(upstream:track)|%(committerdate:relative)", "refs/heads/").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	var stale []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) == 3 && parts[1] == "[gone]" {
+			stale = append(stale, parts[0]+" "+parts[2])
+		}
+	}
+	return strings.Join(stale, "\n"), nil
+}
+
+// RefExists checks if a ref (e.g. refs/remotes/origin/main) exists
+func RefExists(ref string) bool {
+	return exec.Command("git", "show-ref", "--verify", "--quiet", ref).Run() == nil
+}
+
+// GetDefaultBranch returns the default branch of a remote from refs/remotes/<remote>/HEAD (e.g. origin/main).
+// Without a remote HEAD it falls back to a local main or master branch.
+func GetDefaultBranch(remote string) (string, error) {
+	out, err := exec.Command("git", "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD").Output()
+	if err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if RefExists("refs/heads/" + branch) {
+			return branch, nil
+		}
+	}
+	return "", fmt.Errorf("failed to detect the default branch, set it with: git remote set-head %s --auto", remote)
+}
+
+// GetMergedBranches returns the local branches whose commits are all part of base, one per line
+func GetMergedBranches(base string) (string, error) {
+	out, err := exec.Command("git", "branch", "--format=%(refname:short)", "--merged", base).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get merged branches: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsSquashMerged checks if the changes of a branch were squash merged into base.
+// The branch is squashed onto its merge base with a temporary commit (commit-tree),
+// git cherry then compares its patch-id with the commits of base.
+func IsSquashMerged(branch string, base string) (bool, error) {
+	mergeBase, err := exec.Command("git", "merge-base", base, "refs/heads/"+branch).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get merge base of %s: %w", branch, err)
+	}
+
+	tree, err := exec.Command("git", "rev-parse", "refs/heads/"+branch+"^{tree}").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get tree of %s: %w", branch, err)
+	}
+
+	squashed, err := exec.Command("git", "commit-tree", strings.TrimSpace(string(tree)),
+		"-p", strings.TrimSpace(string(mergeBase)), "-m", "squash "+branch).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to squash %s: %w", branch, err)
+	}
+
+	out, err := exec.Command("git", "cherry", base, strings.TrimSpace(string(squashed))).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to compare %s with %s: %w", branch, base, err)
+	}
+	return strings.HasPrefix(strings.TrimSpace(string(out)), "-"), nil
+}
+
+// GetLocalBranches returns all local branches, one per line
+func GetLocalBranches() (string, error) {
+	out, err := exec.Command("git", "branch", "--format=%(refname:short)").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get branches: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RenameBranch renames a local branch with `git branch -m`
+func RenameBranch(oldName string, newName string) (string, error) {
+	out, err := exec.Command("git", "branch", "-m", oldName, newName).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// PushBranch pushes a branch and sets its upstream to the pushed branch
+func PushBranch(remote string, branch string) (string, error) {
+	out, err := exec.Command("git", "push", "--set-upstream", remote, branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// FetchBranch fetches a single branch of a remote, e.g. to start a new branch from an up to date default branch
+func FetchBranch(remote string, branch string) (string, error) {
+	out, err := exec.Command("git", "fetch", remote, branch).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("failed to fetch %s from %s: %w", branch, remote, err)
+	}
+	return string(out), nil
+}
+
+// GetAllBranchRefs returns all local branches followed by all remote branches (e.g. origin/main), one per line
+func GetAllBranchRefs() (string, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads/", "refs/remotes/").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	var refs []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		// skip the symbolic <remote>/HEAD refs
+		if line == "" || strings.HasPrefix(line, "refs/remotes/") && strings.HasSuffix(line, "/HEAD") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "refs/heads/")
+		refs = append(refs, strings.TrimPrefix(line, "refs/remotes/"))
+	}
+	return strings.Join(refs, "\n"), nil
+}
+
+// IsCommit checks if a revision (e.g. a short SHA) names a commit
+func IsCommit(rev string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").Run() == nil
+}
+
+// IsValidBranchName checks a branch name with git check-ref-format
+func IsValidBranchName(name string) bool {
+	return exec.Command("git", "check-ref-format", "--branch", name).Run() == nil
+}
+
+// GetRecentBranches returns the branches in the order they were last checked out, most recent first.
+// It is parsed from the "checkout: moving from <a> to <b>" entries of the HEAD reflog,
+// entries can also be commits (detached HEAD) or deleted branches.
+func GetRecentBranches() ([]string, error) {
+	out, err := exec.Command("git", "reflog", "show", "--format=%gs", "HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the reflog: %w", err)
+	}
+
+	var branches []string
+	for line := range strings.SplitSeq(string(out), "\n") {
+		moving, found := strings.CutPrefix(line, "checkout: moving from ")
+		if !found {
+			continue
+		}
+		from, to, found := strings.Cut(moving, " to ")
+		if !found {
+			continue
+		}
+		for _, branch := range []string{to, from} {
+			if !slices.Contains(branches, branch) {
+				branches = append(branches, branch)
+			}
+		}
+	}
+	return branches, nil
+}
+
+// GetPreviousBranch returns the branch that was checked out before the current one, like `git switch -`
+func GetPreviousBranch() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--symbolic-full-name", "@{-1}").Output()
+	branch, isBranch := strings.CutPrefix(strings.TrimSpace(string(out)), "refs/heads/")
+	if err != nil || !isBranch {
+		return "", fmt.Errorf("no previous branch to switch to")
+	}
+	return branch, nil
+}
+
+// GetRemoteBranchRefs returns all remote branches (e.g. origin/main) without the symbolic <remote>/HEAD, one per line
+func GetRemoteBranchRefs() (string, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/remotes/").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote branches: %w", err)
+	}
+
+	var refs []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		// a symbolic HEAD is shown as just the remote name
+		if strings.Contains(line, "/") && !strings.HasSuffix(line, "/HEAD") {
+			refs = append(refs, line)
+		}
+	}
+	return strings.Join(refs, "\n"), nil
+}
+
+// SetUpstream sets the upstream of a local branch, e.g. to origin/main
+func SetUpstream(branch string, upstream string) (string, error) {
+	out, err := exec.Command("git", "branch", "--set-upstream-to="+upstream, branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// UnsetUpstream removes the upstream of a local branch
+func UnsetUpstream(branch string) (string, error) {
+	out, err := exec.Command("git", "branch", "--unset-upstream", branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// PushToUpstream pushes the current branch to its upstream, see GetUpstreamRef.
+// The branch on the remote is named explicitly, so it may differ from the local one.
+func PushToUpstream(remote string, branch string) (string, error) {
+	out, err := exec.Command("git", "push", remote, "HEAD:refs/heads/"+branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
+
+// PushBranchUntracked pushes a branch to a remote branch with the same name, without setting it as upstream
+func PushBranchUntracked(remote string, branch string) (string, error) {
+	out, err := exec.Command("git", "push", remote, branch).CombinedOutput()
+	if err != nil {
+		return string(out), err
+	}
+	return string(out), nil
+}
@@ -28,3 +28,15 @@ func CommitAll(commitMessage string) (string, error) {
 	}
 	return string(out), nil
 }
+
+// IsHeadPushed checks if the current commit is already part of the upstream branch
+func IsHeadPushed() (bool, error) {
+	err := exec.Command("git", "merge-base", "--is-ancestor", "HEAD", "@{upstream}").Run()
+	if err == nil {
+		return true, nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to compare with upstream: %w", err)
+}
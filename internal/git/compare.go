@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GetMergeBase returns the best common ancestor of two refs as "<short sha> <subject> (<relative date>)"
+func GetMergeBase(a string, b string) (string, error) {
+	out, err := exec.Command("git", "merge-base", a, b).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s and %s have no common history", a, b)
+	}
+	return GetLastCommit(strings.TrimSpace(string(out)))
+}
+
+// GetCommitsOnly returns the commits reachable from ref but not from other, newest first, one per line
+func GetCommitsOnly(ref string, other string) (string, error) {
+	out, err := exec.Command("git", "log", "--format=%h %s (%cr)", other+".."+ref, "--").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to compare %s with %s: %w", ref, other, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// FileStat is the number of added and deleted lines of a file in a diff, -1 for binary files.
+// OldPath is set for renamed files.
+type FileStat struct {
+	Path      string
+	OldPath   string
+	Additions int
+	Deletions int
+}
+
+// GetDiffStat returns the changed files of a diff, e.g. "main...feat/a" for the changes of feat/a since it forked from main
+func GetDiffStat(diffRange string) ([]FileStat, error) {
+	out, err := exec.Command("git", "diff", "--numstat", "-z", "-M", diffRange, "--").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the changed files of %s: %w", diffRange, err)
+	}
+
+	// "<added>\t<deleted>\t<path>\0", renames are "<added>\t<deleted>\t\0<old path>\0<new path>\0"
+	var stats []FileStat
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		fields := strings.SplitN(entries[i], "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		stat := FileStat{Path: fields[2], Additions: -1, Deletions: -1}
+		fmt.Sscan(fields[0], &stat.Additions)
+		fmt.Sscan(fields[1], &stat.Deletions)
+		if stat.Path == "" && i+2 < len(entries) {
+			stat.OldPath, stat.Path = entries[i+1], entries[i+2]
+			i += 2
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DiffFile is the diff of a single file, Lines holds everything after the "diff --git" line
+type DiffFile struct {
+	Path      string
+	Additions int
+	Deletions int
+	Lines     []DiffLine
+}
+
+// DiffLine is a line of a file diff. Hunk is false for the header lines before the first hunk,
+// e.g. "--- a/<path>", so a removed line starting with "--" is not taken for one.
+type DiffLine struct {
+	Text string
+	Hunk bool
+}
+
+// GetDiff returns the diff of a range, e.g. "main...feat/a", optionally limited to some paths
+func GetDiff(diffRange string, paths ...string) (string, error) {
+	return runDiff(append([]string{"diff", diffRange, "--"}, paths...)...)
+}
+
+// GetWorktreeDiff returns the unstaged changes
+func GetWorktreeDiff() (string, error) {
+	return runDiff("diff")
+}
+
+// GetStagedDiff returns the changes staged for the next commit
+func GetStagedDiff() (string, error) {
+	return runDiff("diff", "--cached")
+}
+
+// GetCommitDiff returns the changes of a commit, optionally limited to some paths.
+// Merges are compared with their first parent.
+func GetCommitDiff(rev string, paths ...string) (string, error) {
+	return runDiff(append([]string{"show", "--format=", "--diff-merges=first-parent", rev, "--"}, paths...)...)
+}
+
+func runDiff(args ...string) (string, error) {
+	// the options go right after the subcommand
+	args = append([]string{args[0], "--no-color", "--no-ext-diff", "-M"}, args[1:]...)
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the diff: %s", strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// GetRecentCommits returns the last commits of HEAD as "<short sha> <subject> (<relative date>)", newest first
+func GetRecentCommits(limit int) ([]string, error) {
+	out, err := exec.Command("git", "log", fmt.Sprintf("-%d", limit), "--format=%h %s (%cr)").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the commits: %w", err)
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// IsValidRange checks that a revision or range like "main..feat/a" resolves
+func IsValidRange(diffRange string) bool {
+	return exec.Command("git", "rev-parse", "--quiet", diffRange, "--").Run() == nil
+}
+
+// ParseDiff splits the output of git diff into its files
+func ParseDiff(diff string) []DiffFile {
+	var files []DiffFile
+	inHunk := false
+	for line := range strings.SplitSeq(strings.TrimRight(diff, "\n"), "\n") {
+		if header, ok := strings.CutPrefix(line, "diff --git "); ok {
+			files = append(files, DiffFile{Path: diffHeaderPath(header)})
+			inHunk = false
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		file := &files[len(files)-1]
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "+"):
+			file.Additions++
+		case inHunk && strings.HasPrefix(line, "-"):
+			file.Deletions++
+		case !inHunk && strings.HasPrefix(line, "+++ b/"):
+			file.Path = strings.TrimPrefix(line, "+++ b/")
+		case !inHunk && strings.HasPrefix(line, "rename to "):
+			file.Path = strings.TrimPrefix(line, "rename to ")
+		}
+		file.Lines = append(file.Lines, DiffLine{Text: line, Hunk: inHunk})
+	}
+	return files
+}
+
+// diffHeaderPath returns the new path of a "a/<path> b/<path>" diff header,
+// the "+++" or "rename to" lines replace it for paths with spaces
+func diffHeaderPath(header string) string {
+	if i := strings.LastIndex(header, " b/"); i != -1 {
+		return header[i+3:]
+	}
+	return header
+}
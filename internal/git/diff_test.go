@@ -0,0 +1,128 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []DiffFile
+	}{
+		{
+			name: "hunk lines that look like headers",
+			diff: `diff --git a/notes.md b/notes.md
+index 1111111..2222222 100644
+--- a/notes.md
++++ b/notes.md
+@@ -1,4 +1,4 @@
+ diff --git a/x b/x
+---- removed
+++++ added
+--- a/old
++++ b/new
+`,
+			want: []DiffFile{{
+				Path:      "notes.md",
+				Additions: 2,
+				Deletions: 2,
+				Lines: []DiffLine{
+					{Text: "index 1111111..2222222 100644"},
+					{Text: "--- a/notes.md"},
+					{Text: "+++ b/notes.md"},
+					{Text: "@@ -1,4 +1,4 @@", Hunk: true},
+					{Text: " diff --git a/x b/x", Hunk: true},
+					{Text: "---- removed", Hunk: true},
+					{Text: "++++ added", Hunk: true},
+					{Text: "--- a/old", Hunk: true},
+					{Text: "+++ b/new", Hunk: true},
+				},
+			}},
+		},
+		{
+			name: "multiple files with a rename and a path with spaces",
+			diff: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+-package old
++package main
+ import "fmt"
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+--- a/old name.txt
++++ b/new name.txt
+@@ -1 +1,2 @@
+ keep
++add
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 3333333..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+`,
+			want: []DiffFile{
+				{
+					Path:      "main.go",
+					Additions: 1,
+					Deletions: 1,
+					Lines: []DiffLine{
+						{Text: "index 1111111..2222222 100644"},
+						{Text: "--- a/main.go"},
+						{Text: "+++ b/main.go"},
+						{Text: "@@ -1,2 +1,2 @@", Hunk: true},
+						{Text: "-package old", Hunk: true},
+						{Text: "+package main", Hunk: true},
+						{Text: ` import "fmt"`, Hunk: true},
+					},
+				},
+				{
+					Path:      "new name.txt",
+					Additions: 1,
+					Lines: []DiffLine{
+						{Text: "similarity index 90%"},
+						{Text: "rename from old name.txt"},
+						{Text: "rename to new name.txt"},
+						{Text: "--- a/old name.txt"},
+						{Text: "+++ b/new name.txt"},
+						{Text: "@@ -1 +1,2 @@", Hunk: true},
+						{Text: " keep", Hunk: true},
+						{Text: "+add", Hunk: true},
+					},
+				},
+				{
+					Path:      "gone.txt",
+					Deletions: 1,
+					Lines: []DiffLine{
+						{Text: "deleted file mode 100644"},
+						{Text: "index 3333333..0000000"},
+						{Text: "--- a/gone.txt"},
+						{Text: "+++ /dev/null"},
+						{Text: "@@ -1 +0,0 @@", Hunk: true},
+						{Text: "-bye", Hunk: true},
+					},
+				},
+			},
+		},
+		{
+			name: "empty diff",
+			diff: "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseDiff(tt.diff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDiff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// GraphLine is a line of the commit graph, lines between commits only have the Graph part
+type GraphLine struct {
+	Graph   string
+	Sha     string
+	Refs    string
+	Subject string
+	Date    string
+}
+
+// GetGraph returns the graph of the newest limit commits of all refs, like git log --graph --oneline --all
+func GetGraph(limit int) ([]GraphLine, error) {
+	out, err := exec.Command("git", "log", "--graph", "--all", "--no-color", fmt.Sprintf("-%d", limit),
+		"--format=%x00%h%x00%D%x00%s%x00%cr").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the commit graph: %w", err)
+	}
+
+	var lines []GraphLine
+	for line := range strings.SplitSeq(strings.TrimRight(string(out), "\n"), "\n") {
+		fields := strings.Split(line, "\x00")
+		graphLine := GraphLine{Graph: strings.TrimRight(fields[0], " ")}
+		if len(fields) == 5 {
+			graphLine.Sha, graphLine.Refs, graphLine.Subject, graphLine.Date = fields[1], fields[2], fields[3], fields[4]
+		}
+		lines = append(lines, graphLine)
+	}
+	return lines, nil
+}
+
+// GetCommitDetails returns the header, message and changed files of a commit
+func GetCommitDetails(rev string) (string, error) {
+	out, err := exec.Command("git", "show", "--no-color", "--stat", "--diff-merges=first-parent",
+		"--format=commit %H%nRefs:   %D%nAuthor: %an <%ae>%nDate:   %ad (%ar)%n%n%B", rev, "--").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit %s: %s", rev, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// BlameLine is a line of a file with the commit that last changed it.
+// Previous and PreviousFile are the parent commit and the path of the file in it, empty for the first commit.
+type BlameLine struct {
+	Sha          string
+	Author       string
+	Date         string
+	Summary      string
+	Previous     string
+	PreviousFile string
+	Text         string
+}
+
+// Uncommitted reports whether the line is not committed yet
+func (l BlameLine) Uncommitted() bool {
+	return strings.Trim(l.Sha, "0") == ""
+}
+
+// GetTrackedFiles returns the files tracked in the current directory and below
+func GetTrackedFiles() ([]string, error) {
+	out, err := exec.Command("git", "ls-files").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tracked files: %w", err)
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// GetBlame returns the lines of a file at a revision, the working tree for an empty rev
+func GetBlame(rev string, file string) ([]BlameLine, error) {
+	args := []string{"blame", "--line-porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	out, err := exec.Command("git", append(args, "--", file)...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %s", file, strings.TrimSpace(string(out)))
+	}
+
+	var lines []BlameLine
+	var line BlameLine
+	for row := range strings.SplitSeq(strings.TrimSuffix(string(out), "\n"), "\n") {
+		if text, ok := strings.CutPrefix(row, "\t"); ok {
+			line.Text = text
+			lines = append(lines, line)
+			line = BlameLine{}
+			continue
+		}
+
+		key, value, _ := strings.Cut(row, " ")
+		switch key {
+		case "author":
+			line.Author = value
+		case "author-time":
+			var seconds int64
+			fmt.Sscan(value, &seconds)
+			line.Date = relativeDate(time.Unix(seconds, 0))
+		case "summary":
+			line.Summary = value
+		case "previous":
+			line.Previous, line.PreviousFile, _ = strings.Cut(value, " ")
+		default:
+			if line.Sha == "" && len(key) >= 40 {
+				line.Sha = key[:7]
+			}
+		}
+	}
+	return lines, nil
+}
+
+// relativeDate formats a time like git's relative dates, e.g. "3 days ago"
+func relativeDate(t time.Time) string {
+	seconds := int64(time.Since(t).Seconds())
+	units := []struct {
+		name    string
+		seconds int64
+	}{
+		{"year", 365 * 24 * 3600},
+		{"month", 30 * 24 * 3600},
+		{"week", 7 * 24 * 3600},
+		{"day", 24 * 3600},
+		{"hour", 3600},
+		{"minute", 60},
+	}
+	for _, unit := range units {
+		if count := seconds / unit.seconds; count >= 1 {
+			if count == 1 {
+				return "1 " + unit.name + " ago"
+			}
+			return fmt.Sprintf("%d %ss ago", count, unit.name)
+		}
+	}
+	return "just now"
+}
+
+// FileCommit is a commit that changed a file, Paths are the paths of the file in the commit,
+// both the old and the new path for a rename
+type FileCommit struct {
+	Sha     string
+	Subject string
+	Paths   []string
+}
+
+// GetFileHistory returns the commits that changed a file, newest first, following renames
+func GetFileHistory(path string) ([]FileCommit, error) {
+	out, err := exec.Command("git", "log", "--follow", "-M", "--name-status",
+		"--format=%x00%h%x00%s (%cr, %an)", "--", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the history of %s: %w", path, err)
+	}
+
+	// "\0<sha>\0<subject>\n\n<status>\t<path>[\t<new path>]\n" per commit
+	var commits []FileCommit
+	fields := strings.Split(string(out), "\x00")
+	for i := 1; i+1 < len(fields); i += 2 {
+		subject, status, _ := strings.Cut(fields[i+1], "\n")
+		commit := FileCommit{Sha: fields[i], Subject: subject}
+		for line := range strings.SplitSeq(strings.TrimSpace(status), "\n") {
+			if parts := strings.Split(line, "\t"); len(parts) > 1 {
+				commit.Paths = append(commit.Paths, parts[1:]...)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// HasHistory reports whether any commit changed the path, e.g. for a deleted file
+func HasHistory(path string) bool {
+	out, err := exec.Command("git", "log", "-1", "--format=%h", "--", path).Output()
+	return err == nil && strings.TrimSpace(string(out)) != ""
+}
+
+// GetPathPrefix returns the path of the current directory inside the repository, e.g. "internal/", empty at the top
+func GetPathPrefix() string {
+	out, err := exec.Command("git", "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
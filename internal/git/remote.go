@@ -94,3 +94,44 @@ func RemoveRemote(remote string) (string, string) {
 	}
 	return string(out), ""
 }
+
+func GetRemoteUrl(remote string) (string, string) {
+	out, err := exec.Command("git", "remote", "get-url", remote).CombinedOutput()
+	if err != nil {
+		return "Failed to get remote url", string(out)
+	}
+	return strings.TrimSpace(string(out)), ""
+}
+
+// GetRemoteBranches returns the remote-tracking branches of a remote, one per line
+func GetRemoteBranches(remote string) (string, string) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/remotes/"+remote+"/").CombinedOutput()
+	if err != nil {
+		return "Failed to get remote branches", string(out)
+	}
+
+	var branches []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		// skip the symbolic HEAD, shown as just the remote name
+		if line != "" && line != remote && !strings.HasSuffix(line, "/HEAD") {
+			branches = append(branches, line)
+		}
+	}
+	return strings.Join(branches, "\n"), ""
+}
+
+// GetBranchesTracking returns the local branches with an upstream on the given remote, one per line
+func GetBranchesTracking(remote string) (string, string) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname:short) %(upstream:remotename)", "refs/heads/").CombinedOutput()
+	if err != nil {
+		return "Failed to get tracking branches", string(out)
+	}
+
+	var branches []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if branch, upstreamRemote, ok := strings.Cut(line, " "); ok && upstreamRemote == remote {
+			branches = append(branches, branch)
+		}
+	}
+	return strings.Join(branches, "\n"), ""
+}
@@ -0,0 +1,130 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RepoInfo is a summary of the repository state, shown in the header
+type RepoInfo struct {
+	Branch    string // empty for a detached HEAD
+	Head      string // short SHA of HEAD, empty before the first commit
+	Upstream  string
+	Ahead     int
+	Behind    int
+	Staged    int
+	Unstaged  int
+	Untracked int
+	Conflicts int
+	Stashes   int
+	Operation string // e.g. "merging", empty if no operation is in progress
+	LatestTag string
+}
+
+// GetRepoInfo collects the current branch, tracking and working tree state of the repository
+func GetRepoInfo() (RepoInfo, error) {
+	var info RepoInfo
+
+	out, err := exec.Command("git", "status", "--porcelain=v2", "--branch").Output()
+	if err != nil {
+		return info, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	for line := range strings.SplitSeq(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "#":
+			parseBranchHeader(&info, fields[1:])
+		case "1", "2":
+			// ordinary or renamed entry, XY holds the index and worktree status
+			if fields[1][0] != '.' {
+				info.Staged++
+			}
+			if fields[1][1] != '.' {
+				info.Unstaged++
+			}
+		case "u":
+			info.Conflicts++
+		case "?":
+			info.Untracked++
+		}
+	}
+
+	info.Stashes = countStashes()
+	info.Operation = getOperation()
+	info.LatestTag, _ = GetNLatestTags(1)
+
+	return info, nil
+}
+
+// parseBranchHeader parses a "# branch.*" header line of `git status --porcelain=v2 --branch`
+func parseBranchHeader(info *RepoInfo, fields []string) {
+	if len(fields) < 2 {
+		return
+	}
+
+	switch fields[0] {
+	case "branch.oid":
+		if fields[1] != "(initial)" && len(fields[1]) >= 7 {
+			info.Head = fields[1][:7]
+		}
+	case "branch.head":
+		if fields[1] != "(detached)" {
+			info.Branch = fields[1]
+		}
+	case "branch.upstream":
+		info.Upstream = fields[1]
+	case "branch.ab":
+		if len(fields) == 3 {
+			info.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "+"))
+			info.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "-"))
+		}
+	}
+}
+
+func countStashes() int {
+	out, err := exec.Command("git", "stash", "list").Output()
+	if err != nil {
+		return 0
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return 0
+	}
+	return len(strings.Split(trimmed, "\n"))
+}
+
+// getOperation returns the operation in progress, detected by the state files in the git dir
+func getOperation() string {
+	out, err := exec.Command("git", "rev-parse", "--git-dir").Output()
+	if err != nil {
+		return ""
+	}
+	gitDir := strings.TrimSpace(string(out))
+
+	operations := []struct {
+		file string
+		name string
+	}{
+		{"rebase-merge", "rebasing"},
+		{"rebase-apply", "rebasing"},
+		{"MERGE_HEAD", "merging"},
+		{"CHERRY_PICK_HEAD", "cherry-picking"},
+		{"REVERT_HEAD", "reverting"},
+		{"BISECT_LOG", "bisecting"},
+	}
+	for _, op := range operations {
+		if _, err := os.Stat(filepath.Join(gitDir, op.file)); err == nil {
+			return op.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// graphPageSize is the number of commits of the graph loaded at once,
+// more are loaded when the selection gets close to the end
+const graphPageSize = 200
+
+func (m Model) HandleHistorySelection() (tea.Model, tea.Cmd) {
+	m.HistoryModel.SelectedAction = m.HistoryModel.Actions[m.Selected]
+
+	switch m.HistoryModel.SelectedAction {
+	case "Graph":
+		return m.PrepareGraph()
+	case "Blame":
+		return m.PrepareBlameFileSelection()
+	case "File History":
+		return m.PrepareFileHistoryPathSelection()
+	}
+	return m, nil
+}
+
+// PrepareGraph loads the first page of the commit graph and selects the newest commit
+func (m *Model) PrepareGraph() (*Model, tea.Cmd) {
+	m.GraphModel = GraphModel{Limit: graphPageSize}
+	if err := m.loadGraph(); err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+
+	commits := m.graphCommits()
+	if len(commits) == 0 {
+		m.Err = "No commits yet"
+		return m, m.finish()
+	}
+
+	m.GraphModel.Selected = commits[0]
+	m.CurrentStep = StepGraph
+	m.Level = 3
+	return m, nil
+}
+
+// loadGraph loads the graph up to the current limit, keeping the selected commit selected
+func (m *Model) loadGraph() error {
+	selected := ""
+	if m.GraphModel.Selected < len(m.GraphModel.Lines) {
+		selected = m.GraphModel.Lines[m.GraphModel.Selected].Sha
+	}
+
+	lines, err := git.GetGraph(m.GraphModel.Limit)
+	if err != nil {
+		return err
+	}
+	m.GraphModel.Lines = lines
+	m.GraphModel.Complete = len(m.graphCommits()) < m.GraphModel.Limit
+
+	for i, line := range lines {
+		if selected != "" && line.Sha == selected {
+			m.GraphModel.Selected = i
+		}
+	}
+	return nil
+}
+
+// graphCommits returns the indices of the graph lines with a commit
+func (m Model) graphCommits() []int {
+	var commits []int
+	for i, line := range m.GraphModel.Lines {
+		if line.Sha != "" {
+			commits = append(commits, i)
+		}
+	}
+	return commits
+}
+
+// handleGraphKey moves the selection between the commits of the graph and loads the next page when needed
+func (m *Model) handleGraphKey(msg tea.KeyMsg) {
+	commits := m.graphCommits()
+	position := 0
+	for i, line := range commits {
+		if line == m.GraphModel.Selected {
+			position = i
+		}
+	}
+
+	switch {
+	case key.Matches(msg, m.Keys.Up):
+		position = max(position-1, 0)
+	case key.Matches(msg, m.Keys.Down):
+		position = min(position+1, len(commits)-1)
+	case key.Matches(msg, m.Keys.PageUp):
+		position = max(position-m.pageSize(), 0)
+	case key.Matches(msg, m.Keys.PageDown):
+		position = min(position+m.pageSize(), len(commits)-1)
+	case key.Matches(msg, m.Keys.Home):
+		position = 0
+	case key.Matches(msg, m.Keys.End):
+		position = len(commits) - 1
+	}
+	m.GraphModel.Selected = commits[position]
+
+	if !m.GraphModel.Complete && position >= len(commits)-m.pageSize() {
+		m.GraphModel.Limit += graphPageSize
+		if err := m.loadGraph(); err != nil {
+			// keep the loaded part, the next key tries again
+			m.GraphModel.Limit -= graphPageSize
+		}
+	}
+}
+
+func (m Model) HandleGraphSelection() (tea.Model, tea.Cmd) {
+	line := m.GraphModel.Lines[m.GraphModel.Selected]
+	return m.ShowCommitDetails(line.Sha + " " + line.Subject)
+}
+
+// ShowCommitDetails shows the header, message and changed files of a commit one level below the current one.
+// commit starts with the SHA, e.g. "a1b2c3d feat: add login".
+func (m *Model) ShowCommitDetails(commit string) (*Model, tea.Cmd) {
+	m.HistoryModel.SelectedCommit = commit
+	m.Level++
+
+	details, err := git.GetCommitDetails(branchName(commit))
+	if err != nil {
+		m.Err = err.Error()
+		return m, m.finish()
+	}
+
+	m.OutputByLevel(formatCommitDetails(details))
+	m.OutputScroll = 0
+	m.CurrentStep = StepCommitDetails
+	return m, nil
+}
+
+// formatCommitDetails adds color codes to the output of git.GetCommitDetails
+func formatCommitDetails(details string) string {
+	var lines []string
+	header := true
+	for i, line := range strings.Split(strings.TrimRight(details, "\n"), "\n") {
+		switch {
+		case i == 0:
+			line = "\\ca" + line
+		case header && line == "":
+			header = false
+		case header && strings.TrimSpace(line) == "Refs:":
+			// a commit without branches or tags
+			continue
+		case header:
+		case strings.TrimSpace(line) == "":
+		case strings.HasPrefix(line, " ") && (strings.Contains(line, " | ") || strings.Contains(line, " changed")):
+			// the changed files
+		default:
+			line = "\\ct    " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// graphPosition describes the selected commit of the graph, e.g. "12 of 200+"
+func (m Model) graphPosition() string {
+	commits := m.graphCommits()
+	position := 0
+	for i, line := range commits {
+		if line <= m.GraphModel.Selected {
+			position = i + 1
+		}
+	}
+
+	total := fmt.Sprintf("%d", len(commits))
+	if !m.GraphModel.Complete {
+		total += "+"
+	}
+	return fmt.Sprintf("%d of %s", position, total)
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/a3chron/gith/internal/ui"
+)
+
+// startInput replaces the active text input with a new one for the next input step.
+// The validation error is shown once the value is not empty or after a submit.
+func (m *Model) startInput(value string, placeholder string, validate textinput.ValidateFunc) {
+	field := textinput.New()
+	field.Prompt = "> "
+	field.PromptStyle = ui.AccentStyle
+	field.TextStyle = ui.TextStyle
+	field.Cursor.Style = ui.AccentStyle
+	field.Cursor.SetMode(cursor.CursorStatic)
+	field.Validate = validate
+	field.Focus()
+	field.SetValue(value)
+
+	m.Input = InputModel{
+		Field:       field,
+		Placeholder: placeholder,
+	}
+}
+
+// updateInput passes a message to the active text input and keeps the
+// value of the current step in sync with it
+func (m *Model) updateInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.Input.Field, cmd = m.Input.Field.Update(msg)
+
+	value := m.Input.Field.Value()
+	switch m.CurrentStep {
+	case StepTagInput:
+		m.TagModel.ManualInput = value
+	case StepBranchInput:
+		m.BranchModel.Input = value
+	case StepBranchTemplateInput:
+		m.BranchModel.FieldInput = value
+	case StepBranchBaseInput:
+		m.BranchModel.BaseInput = value
+	case StepRemoteNameInput:
+		m.RemoteModel.NameInput = value
+	case StepRemoteUrlInput:
+		m.RemoteModel.UrlInput = value
+	case StepCommitInput:
+		m.CommitModel.CommitMessage = value
+	case StepChangesRangeInput:
+		m.ChangesModel.RangeInput = value
+	case StepFileHistoryInput:
+		m.FileHistoryModel.PathInput = value
+	}
+
+	return cmd
+}
+
+// inputError returns the validation error of the active input, if it should be shown
+func (m Model) inputError() error {
+	if m.Input.Field.Value() == "" && !m.Input.Submitted {
+		return nil
+	}
+	return m.Input.Field.Err
+}
+
+func validateNotEmpty(name string) textinput.ValidateFunc {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New(name + " cannot be empty")
+		}
+		return nil
+	}
+}
+
+// validateNoSpaces is used for names that git does not allow whitespace in
+func validateNoSpaces(name string) textinput.ValidateFunc {
+	return func(value string) error {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return errors.New(name + " cannot be empty")
+		}
+		if strings.ContainsAny(value, " \t") {
+			return errors.New(name + " cannot contain spaces")
+		}
+		return nil
+	}
+}
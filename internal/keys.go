@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/a3chron/gith/internal/config"
+)
+
+// KeyMap holds the active key bindings, built from the keymap in the config
+type KeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Home       key.Binding
+	End        key.Binding
+	Select     key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+	Filter     key.Binding
+	OutputUp   key.Binding
+	OutputDown key.Binding
+	Toggle     key.Binding
+	Previous   key.Binding
+	NextFile   key.Binding
+	PrevFile   key.Binding
+	Parent     key.Binding
+}
+
+func NewKeyMap(keymap config.Keymap) KeyMap {
+	return KeyMap{
+		Up:         newBinding(keymap.Up, config.DefaultKeymap.Up),
+		Down:       newBinding(keymap.Down, config.DefaultKeymap.Down),
+		PageUp:     newBinding(keymap.PageUp, config.DefaultKeymap.PageUp),
+		PageDown:   newBinding(keymap.PageDown, config.DefaultKeymap.PageDown),
+		Home:       newBinding(keymap.Home, config.DefaultKeymap.Home),
+		End:        newBinding(keymap.End, config.DefaultKeymap.End),
+		Select:     newBinding(keymap.Select, config.DefaultKeymap.Select),
+		Back:       newBinding(keymap.Back, config.DefaultKeymap.Back),
+		Quit:       newBinding(keymap.Quit, config.DefaultKeymap.Quit),
+		Filter:     newBinding(keymap.Filter, config.DefaultKeymap.Filter),
+		OutputUp:   newBinding(keymap.OutputUp, config.DefaultKeymap.OutputUp),
+		OutputDown: newBinding(keymap.OutputDown, config.DefaultKeymap.OutputDown),
+		Toggle:     newBinding(keymap.Toggle, config.DefaultKeymap.Toggle),
+		Previous:   newBinding(keymap.Previous, config.DefaultKeymap.Previous),
+		NextFile:   newBinding(keymap.NextFile, config.DefaultKeymap.NextFile),
+		PrevFile:   newBinding(keymap.PrevFile, config.DefaultKeymap.PrevFile),
+		Parent:     newBinding(keymap.Parent, config.DefaultKeymap.Parent),
+	}
+}
+
+func newBinding(keys []string, fallback []string) key.Binding {
+	if len(keys) == 0 {
+		keys = fallback
+	}
+
+	// bubbletea reports the space key as " "
+	keys = slices.Clone(keys)
+	for i, k := range keys {
+		if k == "space" {
+			keys[i] = " "
+		}
+	}
+
+	return key.NewBinding(key.WithKeys(keys...))
+}
+
+// isTextKey returns true for keys that type text, these are never
+// treated as bindings while an input is active
+func isTextKey(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace
+}
+
+// matchesInInput is like key.Matches, but ignores text keys so that e.g. a
+// "q" quit binding can still be typed into an input
+func matchesInInput(msg tea.KeyMsg, binding key.Binding) bool {
+	return !isTextKey(msg) && key.Matches(msg, binding)
+}
+
+// keyHint formats the keys of a binding for the navigation hints, e.g. "q / esc".
+// ctrl+c always quits and is left out to keep the hints short.
+func keyHint(binding key.Binding, inInput bool) string {
+	var keys []string
+	for _, k := range binding.Keys() {
+		if k == "ctrl+c" || (inInput && len([]rune(k)) == 1) {
+			continue
+		}
+		keys = append(keys, displayKey(k))
+	}
+	if len(keys) == 0 {
+		return displayKey(binding.Keys()[0])
+	}
+	return strings.Join(keys, " / ")
+}
+
+// navigationHint formats the primary up and down (or previous and next) keys, e.g. "↑↓", "←→" or "k/j"
+func navigationHint(up, down key.Binding) string {
+	upKey, downKey := displayKey(up.Keys()[0]), displayKey(down.Keys()[0])
+	if (upKey == "↑" && downKey == "↓") || (upKey == "←" && downKey == "→") {
+		return upKey + downKey
+	}
+	return upKey + "/" + downKey
+}
+
+func displayKey(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	}
+	return k
+}
@@ -2,7 +2,9 @@ package internal
 
 import (
 	"github.com/a3chron/gith/internal/config"
+	"github.com/a3chron/gith/internal/git"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 )
 
 type Step int
@@ -15,6 +17,15 @@ const (
 	StepBranchSelect
 	StepBranchCreate
 	StepBranchInput
+	StepBranchTemplateInput
+	StepBranchBase
+	StepBranchBaseSelect
+	StepBranchBaseInput
+	StepBranchSwitch
+
+	StepCompareLeft
+	StepCompareRight
+	StepCompareFiles
 
 	StepCommitAction
 	StepCommitSelectPrefix
@@ -31,6 +42,21 @@ const (
 	StepRemoteUrlInput
 
 	StepChanges
+	StepChangesCommit
+	StepChangesRangeInput
+
+	StepDiff
+
+	StepHistory
+	StepGraph
+	StepBlameFile
+	StepBlame
+	StepFileHistoryPath
+	StepFileHistoryInput
+	StepFileHistory
+	StepCommitDetails
+
+	StepConfirm
 
 	StepOptions
 	StepOptionsFlavorSelect
@@ -51,6 +77,21 @@ type BranchModel struct {
 	Options        []string
 	SelectedOption string
 	Input          string
+	DeleteRemote   bool
+	BaseBranch     string
+	Bases          []string
+	SelectedBase   string
+	BaseRefs       []string
+	BaseInput      string
+	SwitchOptions  []string
+	SelectedSwitch string
+	Template       string
+	TemplateFields []string
+	FieldValues    []string
+	FieldInput     string
+	Current        string
+	Upstream       string
+	Remotes        []string
 }
 
 type CommitModel struct {
@@ -59,6 +100,66 @@ type CommitModel struct {
 	CommitPrefixes []string
 	SelectedPrefix string
 	CommitMessage  string
+	Ticket         string
+}
+
+// CompareModel holds the two refs of the Compare branch action and the files changed between them
+type CompareModel struct {
+	LeftRefs     []string
+	Left         string
+	RightRefs    []string
+	Right        string
+	Files        []git.FileStat
+	FileOptions  []string
+	SelectedFile string
+}
+
+type ChangesModel struct {
+	Actions        []string
+	SelectedAction string
+	Commits        []string
+	SelectedCommit string
+	RangeInput     string
+}
+
+type HistoryModel struct {
+	Actions        []string
+	SelectedAction string
+	SelectedCommit string
+}
+
+// GraphModel holds the loaded part of the commit graph, Selected is the index of a line with a commit
+type GraphModel struct {
+	Lines    []git.GraphLine
+	Selected int
+	Limit    int
+	Complete bool
+}
+
+// BlameModel holds the blamed file, Rev is the revision it is blamed at, empty for the working tree
+type BlameModel struct {
+	Files    []string
+	File     string
+	Rev      string
+	Lines    []git.BlameLine
+	Selected int
+}
+
+// FileHistoryModel holds the file of File History and the commits that changed it
+type FileHistoryModel struct {
+	Paths          []string
+	Path           string
+	PathInput      string
+	Commits        []git.FileCommit
+	Options        []string
+	SelectedCommit string
+}
+
+// DiffModel holds the diff pane, the files of the diff and the position in it
+type DiffModel struct {
+	Files  []git.DiffFile
+	File   int
+	Scroll int
 }
 
 type TagModel struct {
@@ -92,25 +193,84 @@ type ConfigModel struct {
 	SelectedBehaviour string
 }
 
+// ConfirmModel holds the confirmation step shown before destructive operations
+type ConfirmModel struct {
+	Title   string
+	Lines   []string
+	Options []string
+	Answer  string
+	Action  string
+}
+
+// RepoInfoModel holds the repository state shown in the header
+type RepoInfoModel struct {
+	Info    git.RepoInfo
+	Loading bool
+	Err     string
+}
+
+// ResultModel holds the result of the last flow, shown in persistent mode
+type ResultModel struct {
+	Title   string
+	Output  string
+	Err     string
+	Success string
+}
+
+// InputModel holds the text input of the current input step
+type InputModel struct {
+	Field       textinput.Model
+	Placeholder string
+	Submitted   bool
+}
+
 type Model struct {
-	CurrentStep   Step
-	Loading       bool
-	Selected      int
-	ActionModel   ActionModel
-	BranchModel   BranchModel
-	CommitModel   CommitModel
-	RemoteModel   RemoteModel
-	TagModel      TagModel
-	ConfigModel   ConfigModel
-	CurrentConfig *config.Config
-	Spinner       spinner.Model
-	Level         int
-	Output        []string
-	Err           string
-	Success       string
-	StartAt       string
-	StartAtLevel  int
+	CurrentStep      Step
+	Loading          bool
+	Selected         int
+	ActionModel      ActionModel
+	BranchModel      BranchModel
+	CompareModel     CompareModel
+	CommitModel      CommitModel
+	RemoteModel      RemoteModel
+	TagModel         TagModel
+	ChangesModel     ChangesModel
+	DiffModel        DiffModel
+	HistoryModel     HistoryModel
+	GraphModel       GraphModel
+	BlameModel       BlameModel
+	FileHistoryModel FileHistoryModel
+	ConfigModel      ConfigModel
+	Input            InputModel
+	Filter           FilterModel
+	ConfirmModel     ConfirmModel
+	Marked           []string
+	CurrentConfig    *config.Config
+	Keys             KeyMap
+	Spinner          spinner.Model
+	Level            int
+	Output           []string
+	OutputScroll     int
+	Width            int
+	Height           int
+	Err              string
+	Success          string
+	StartAt          string
+	StartAtLevel     int
+	Persistent       bool
+	SkipConfirm      bool
+	LastResult       ResultModel
+	RepoInfo         RepoInfoModel
+
+	// History holds the state before each step transition, see goBack
+	History []Model
 }
 
 type RepoUpdatedMsg struct{}
 type RepoUpdateErrorMsg struct{}
+
+// RepoInfoMsg carries the repository state for the header
+type RepoInfoMsg struct {
+	Info git.RepoInfo
+	Err  error
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// isMultiSelectStep returns true for selection lists that allow marking several entries
+func (m Model) isMultiSelectStep() bool {
+	switch m.CurrentStep {
+	case StepBranchSelect:
+		switch m.BranchModel.SelectedAction {
+		case "Delete Branch", "Prune Stale Branches", "Clean Up Merged":
+			return true
+		}
+	case StepTagSelect:
+		return m.TagModel.SelectedAction == "Remove Tag"
+	}
+	return false
+}
+
+// isMarkable returns false for entries of a list that are not real items, e.g. "Load all tags"
+func isMarkable(option string) bool {
+	return option != "Load all tags" && option != "Only load 10 latest tags"
+}
+
+// toggleMark marks or unmarks the selected option
+func (m *Model) toggleMark() {
+	index := m.selectedOptionIndex()
+	if index == -1 {
+		return
+	}
+
+	option := m.getCurrentOptions()[index]
+	if !isMarkable(option) {
+		return
+	}
+
+	if i := slices.Index(m.Marked, option); i != -1 {
+		m.Marked = slices.Delete(slices.Clone(m.Marked), i, i+1)
+	} else {
+		m.Marked = append(slices.Clone(m.Marked), option)
+	}
+}
+
+func (m Model) isMarked(option string) bool {
+	return slices.Contains(m.Marked, option)
+}
+
+// batchResult collects the per item results of a batch operation
+type batchResult struct {
+	lines     []string
+	succeeded int
+	failed    int
+}
+
+func (r *batchResult) add(item string, out string, err error) {
+	if err == nil {
+		r.succeeded++
+		r.lines = append(r.lines, "\\cg✓ "+item)
+		return
+	}
+
+	r.failed++
+	reason := strings.TrimSpace(strings.Split(strings.TrimSpace(out), "\n")[0])
+	if reason == "" {
+		reason = err.Error()
+	}
+	r.lines = append(r.lines, "\\cr✗ "+item, "  "+reason)
+}
+
+// finish writes the summary to the output and sets the result message,
+// e.g. "Deleted 3 Branches" or "Deleted 2 of 3 Branches"
+func (r *batchResult) finish(m *Model, verb string, noun string) {
+	m.OutputByLevel(strings.Join(r.lines, "\n"))
+
+	total := r.succeeded + r.failed
+	if r.failed == 0 {
+		m.Success = fmt.Sprintf("%s %d %s", verb, total, noun)
+	} else {
+		m.Err = fmt.Sprintf("%s %d of %d %s", verb, r.succeeded, total, noun)
+	}
+}
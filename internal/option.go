@@ -14,7 +14,7 @@ func (m Model) HandleOptionsActionSelection() (tea.Model, tea.Cmd) {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			m.Err = fmt.Sprintf("Failed to load config: %v", err)
-			return m, tea.Quit
+			return m, m.finish()
 		}
 		m.CurrentConfig = cfg
 	}
@@ -61,18 +61,15 @@ func (m Model) HandleOptionsActionSelection() (tea.Model, tea.Cmd) {
 		m.Level = 3
 
 	case "Reset to Defaults":
-		m.CurrentConfig = &config.Config{
-			Accent:        config.DefaultConfig.Accent,
-			Flavor:        config.DefaultConfig.Flavor,
-			InitBehaviour: config.DefaultConfig.InitBehaviour,
-		}
+		m.CurrentConfig = config.NewDefaultConfig()
+		m.Keys = NewKeyMap(m.CurrentConfig.Keymap)
 		if err := config.SaveConfig(m.CurrentConfig); err != nil {
 			m.Err = fmt.Sprintf("Failed to save config: %v", err)
 		} else {
 			ui.UpdateStylesByConfig(m.CurrentConfig)
 			m.Success = "Configuration reset to defaults"
 		}
-		return m, tea.Quit
+		return m, m.finish()
 	}
 	return m, nil
 }
@@ -83,7 +80,7 @@ func (m Model) HandleOptionsFlavorSelection() (tea.Model, tea.Cmd) {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			m.Err = fmt.Sprintf("Failed to load config: %v", err)
-			return m, tea.Quit
+			return m, m.finish()
 		}
 		m.CurrentConfig = cfg
 	}
@@ -93,13 +90,14 @@ func (m Model) HandleOptionsFlavorSelection() (tea.Model, tea.Cmd) {
 
 	// Update config and save
 	m.CurrentConfig.Flavor = selectedFlavor
-	if err := config.SaveConfig(m.CurrentConfig); err != nil {
+	m.CurrentConfig.MarkUpdated("flavor")
+	if err := config.SaveUpdates(m.CurrentConfig); err != nil {
 		m.Err = fmt.Sprintf("Failed to save config: %v", err)
 	} else {
 		ui.UpdateStylesByConfig(m.CurrentConfig)
 		m.Success = fmt.Sprintf("Flavor changed to %s", selectedFlavor)
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
 
 func (m Model) HandleOptionsAccentSelection() (tea.Model, tea.Cmd) {
@@ -108,7 +106,7 @@ func (m Model) HandleOptionsAccentSelection() (tea.Model, tea.Cmd) {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			m.Err = fmt.Sprintf("Failed to load config: %v", err)
-			return m, tea.Quit
+			return m, m.finish()
 		}
 		m.CurrentConfig = cfg
 	}
@@ -118,13 +116,14 @@ func (m Model) HandleOptionsAccentSelection() (tea.Model, tea.Cmd) {
 
 	// Update config and save
 	m.CurrentConfig.Accent = selectedAccent
-	if err := config.SaveConfig(m.CurrentConfig); err != nil {
+	m.CurrentConfig.MarkUpdated("accent")
+	if err := config.SaveUpdates(m.CurrentConfig); err != nil {
 		m.Err = fmt.Sprintf("Failed to save config: %v", err)
 	} else {
 		ui.UpdateStylesByConfig(m.CurrentConfig)
 		m.Success = fmt.Sprintf("Accent changed to %s", selectedAccent)
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
 
 func (m Model) HandleOptionsInitBehaviourSelection() (tea.Model, tea.Cmd) {
@@ -133,7 +132,7 @@ func (m Model) HandleOptionsInitBehaviourSelection() (tea.Model, tea.Cmd) {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			m.Err = fmt.Sprintf("Failed to load config: %v", err)
-			return m, tea.Quit
+			return m, m.finish()
 		}
 		m.CurrentConfig = cfg
 	}
@@ -142,10 +141,11 @@ func (m Model) HandleOptionsInitBehaviourSelection() (tea.Model, tea.Cmd) {
 
 	// Update config and save
 	m.CurrentConfig.InitBehaviour = m.ConfigModel.SelectedBehaviour
-	if err := config.SaveConfig(m.CurrentConfig); err != nil {
+	m.CurrentConfig.MarkUpdated("init")
+	if err := config.SaveUpdates(m.CurrentConfig); err != nil {
 		m.Err = fmt.Sprintf("Failed to save config: %v", err)
 	} else {
 		m.Success = fmt.Sprintf("Init Behaviour changed to %s", m.ConfigModel.SelectedBehaviour)
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
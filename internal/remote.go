@@ -15,6 +15,9 @@ func (m Model) HandleRemoteActionSelection() (tea.Model, tea.Cmd) {
 
 func (m Model) HandleRemoteSelection() (tea.Model, tea.Cmd) {
 	m.RemoteModel.SelectedOption = m.RemoteModel.Options[m.Selected]
+	if m.RemoteModel.SelectedAction == "Remove Remote" {
+		return m.confirm(fmt.Sprintf("Remove remote '%s'?", m.RemoteModel.SelectedOption), remoteRemoveDetails(m.RemoteModel.SelectedOption), "remove-remote")
+	}
 	return m.ExecuteRemoteAction()
 }
 
@@ -29,11 +32,12 @@ func (m *Model) HandleRemoteOperation() (*Model, tea.Cmd) {
 			m.OutputByLevel(git.FormatFancyRemotes(out))
 			m.Success = "Listed all Remotes"
 		}
-		return m, tea.Quit
+		return m, m.finish()
 
 	case "Add Remote":
 		m.CurrentStep = StepRemoteNameInput
 		m.Level = 3
+		m.startInput("", "e.g. origin", validateNoSpaces("Remote name"))
 		return m, nil
 
 	case "Remove Remote":
@@ -48,13 +52,13 @@ func (m *Model) PrepareRemoteSelection() (*Model, tea.Cmd) {
 	if err != "" {
 		m.OutputByLevel("\\crError:\n" + err)
 		m.Err = out
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	if strings.TrimSpace(out) == "" {
 		m.OutputByLevel("You can add remotes with Remote -> Add Remote or `git remote add <remote_name> <remote_url>`")
 		m.Err = "No remotes found"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	m.Selected = 0
@@ -77,5 +81,5 @@ func (m *Model) ExecuteRemoteAction() (*Model, tea.Cmd) {
 			m.Success = fmt.Sprintf("Removed Remote '%s'", m.RemoteModel.SelectedOption)
 		}
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FlowFinishedMsg is sent in persistent mode once a flow finished
+type FlowFinishedMsg struct{}
+
+// finish ends the current flow. Outside of persistent mode gith quits,
+// in persistent mode the result is shown and the action selection starts again.
+func (m *Model) finish() tea.Cmd {
+	if !m.Persistent {
+		return tea.Quit
+	}
+	return func() tea.Msg { return FlowFinishedMsg{} }
+}
+
+// returnToMenu keeps the result of the finished flow and resets to the action selection
+func (m *Model) returnToMenu() {
+	var output []string
+	for _, out := range m.Output {
+		if strings.TrimSpace(out) != "" {
+			output = append(output, out)
+		}
+	}
+
+	m.LastResult = ResultModel{
+		Title:   m.flowTitle(),
+		Output:  strings.Join(output, "\n"),
+		Err:     m.Err,
+		Success: m.Success,
+	}
+
+	m.resetState()
+	m.History = nil
+}
+
+// flowTitle describes the current flow, e.g. "Branch › Delete Branch"
+func (m Model) flowTitle() string {
+	var parts []string
+	for _, part := range []string{
+		m.ActionModel.SelectedAction,
+		m.BranchModel.SelectedAction,
+		m.CommitModel.SelectedAction,
+		m.TagModel.SelectedAction,
+		m.RemoteModel.SelectedAction,
+		m.ConfigModel.SelectedAction,
+	} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " › ")
+}
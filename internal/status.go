@@ -36,5 +36,5 @@ func (m *Model) ExecuteStatus() (*Model, tea.Cmd) {
 			}())
 		}
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
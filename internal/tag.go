@@ -15,7 +15,15 @@ func (m Model) HandleTagActionSelection() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) HandleTagSelection() (tea.Model, tea.Cmd) {
+	if len(m.Marked) > 0 && isMarkable(m.TagModel.Options[m.Selected]) {
+		m.TagModel.SelectedOption = strings.Join(m.Marked, ", ")
+		return m.confirm(fmt.Sprintf("Remove %d tags?", len(m.Marked)), m.Marked, "remove-tags")
+	}
+
 	m.TagModel.SelectedOption = m.TagModel.Options[m.Selected]
+	if m.TagModel.SelectedAction == "Remove Tag" && isMarkable(m.TagModel.SelectedOption) {
+		return m.confirm(fmt.Sprintf("Remove tag '%s'?", m.TagModel.SelectedOption), tagRemoveDetails(m.TagModel.SelectedOption), "remove-tag")
+	}
 	return m.ExecuteTagAction()
 }
 
@@ -29,7 +37,7 @@ func (m *Model) HandleTagOperation() (*Model, tea.Cmd) {
 		} else {
 			m.Success = "Listed Tags"
 		}
-		return m, tea.Quit
+		return m, m.finish()
 
 	case "Add Tag":
 		return m.PrepareTagAddition()
@@ -53,13 +61,13 @@ func (m *Model) PrepareTagSelection() (*Model, tea.Cmd) {
 	if err != nil {
 		m.OutputByLevel("\nError:\n" + fmt.Sprintf("%v", err))
 		m.Err = "Failed to get tags"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	if strings.TrimSpace(out) == "" {
 		m.OutputByLevel("You can add tags with Tags -> Add Tag or `git tag <tag_name>`")
 		m.Err = "No tags found"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	m.Selected = 0
@@ -77,7 +85,7 @@ func (m *Model) PrepareTagAddition() (*Model, tea.Cmd) {
 	if err != nil {
 		m.OutputByLevel("\\crError:\n" + fmt.Sprintf("%v", err))
 		m.Err = "Failed to get latest tag"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	latestTag := strings.TrimSpace(out)
@@ -123,6 +131,7 @@ func (m Model) HandleTagAddSelection() (tea.Model, tea.Cmd) {
 		// Switch to input mode
 		m.TagModel.ManualInput = ""
 		m.CurrentStep = StepTagInput
+		m.startInput("", "e.g. v1.0.0", validateNoSpaces("Tag name"))
 		return m, nil
 	} else {
 		// Extract the version from the option (e.g., "Patch (v1.2.4)" -> "v1.2.4")
@@ -141,10 +150,10 @@ func (m Model) HandleTagAddSelection() (tea.Model, tea.Cmd) {
 				m.Success = "Created new Tag"
 			}
 
-			return m, tea.Quit
+			return m, m.finish()
 		} else {
 			m.Err = "Failed to parse version from selection"
-			return m, tea.Quit
+			return m, m.finish()
 		}
 	}
 }
@@ -153,7 +162,7 @@ func (m Model) HandleTagAddSelection() (tea.Model, tea.Cmd) {
 func (m Model) HandleTagInputSubmit() (tea.Model, tea.Cmd) {
 	if strings.TrimSpace(m.TagModel.ManualInput) == "" {
 		m.Err = "Tag name cannot be empty"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	out, err := git.CreateTag(strings.TrimSpace(m.TagModel.ManualInput))
@@ -166,7 +175,7 @@ func (m Model) HandleTagInputSubmit() (tea.Model, tea.Cmd) {
 		m.Success = "Created Tag"
 	}
 
-	return m, tea.Quit
+	return m, m.finish()
 }
 
 func (m *Model) ExecuteTagAction() (*Model, tea.Cmd) {
@@ -215,7 +224,7 @@ func (m *Model) ExecuteTagAction() (*Model, tea.Cmd) {
 			}
 		}
 	}
-	return m, tea.Quit
+	return m, m.finish()
 }
 
 func (m *Model) LoadAllTags() (*Model, tea.Cmd) {
@@ -224,13 +233,13 @@ func (m *Model) LoadAllTags() (*Model, tea.Cmd) {
 	if err != nil {
 		m.OutputByLevel("\nError:\n" + fmt.Sprintf("%v", err))
 		m.Err = "Failed to get tags"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	if strings.TrimSpace(out) == "" {
 		m.OutputByLevel("You can add tags with Tags -> Add Tag or `git tag <tag_name>`")
 		m.Err = "No tags found"
-		return m, tea.Quit
+		return m, m.finish()
 	}
 
 	m.Selected = 0
@@ -240,3 +249,15 @@ func (m *Model) LoadAllTags() (*Model, tea.Cmd) {
 	m.Level = 3
 	return m, nil
 }
+
+// ExecuteBatchTagRemove removes all marked tags and shows a summary
+func (m *Model) ExecuteBatchTagRemove() (*Model, tea.Cmd) {
+	var result batchResult
+	for _, tag := range m.Marked {
+		out, err := git.DeleteTag(tag)
+		result.add(tag, out, err)
+	}
+
+	result.finish(m, "Removed", "Tags")
+	return m, m.finish()
+}
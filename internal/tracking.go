@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a3chron/gith/internal/git"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// unsetUpstream is the Tracking option to remove the upstream of the current branch
+const unsetUpstream = "Unset upstream"
+
+// loadCurrentTracking sets the current branch and its upstream, which is empty if there is none
+func (m *Model) loadCurrentTracking() bool {
+	current, err := git.GetCurrentBranch()
+	if err != nil || current == "" {
+		m.Err = "Not on a branch (detached HEAD)"
+		return false
+	}
+
+	upstream, err := git.GetUpstream(current)
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return false
+	}
+
+	m.BranchModel.Current = current
+	m.BranchModel.Upstream = upstream
+	return true
+}
+
+// PrepareTrackingSelection lists the remote branches the current branch can track
+func (m *Model) PrepareTrackingSelection() (*Model, tea.Cmd) {
+	if !m.loadCurrentTracking() {
+		return m, m.finish()
+	}
+
+	refs, err := git.GetRemoteBranchRefs()
+	if err != nil {
+		m.Err = fmt.Sprintf("%v", err)
+		return m, m.finish()
+	}
+
+	var options []string
+	if m.BranchModel.Upstream != "" {
+		options = append(options, unsetUpstream)
+	}
+	for ref := range strings.SplitSeq(refs, "\n") {
+		if ref == "" {
+			continue
+		}
+		if ref == m.BranchModel.Upstream {
+			ref += " (current)"
+		}
+		options = append(options, ref)
+	}
+
+	if len(options) == 0 {
+		m.Err = "No remote branches to track, push the branch first"
+		return m, m.finish()
+	}
+
+	m.BranchModel.Branches = options
+	m.Selected = 0
+	m.CurrentStep = StepBranchSelect
+	m.Level = 3
+	return m, nil
+}
+
+// ExecuteTracking sets the selected remote branch as upstream of the current branch, or unsets it
+func (m *Model) ExecuteTracking() (*Model, tea.Cmd) {
+	current := m.BranchModel.Current
+
+	if m.BranchModel.SelectedBranch == unsetUpstream {
+		out, err := git.UnsetUpstream(current)
+		m.OutputByLevel(out)
+		if err != nil {
+			m.Err = "Failed to unset the upstream"
+		} else {
+			m.Success = fmt.Sprintf("'%s' no longer tracks %s", current, m.BranchModel.Upstream)
+		}
+		return m, m.finish()
+	}
+
+	out, err := git.SetUpstream(current, m.BranchModel.SelectedBranch)
+	m.OutputByLevel(out)
+	if err != nil {
+		m.Err = "Failed to set the upstream"
+	} else {
+		m.Success = fmt.Sprintf("'%s' now tracks %s", current, m.BranchModel.SelectedBranch)
+	}
+	return m, m.finish()
+}
+
+// PreparePush pushes the current branch to its upstream.
+// Without an upstream, it asks where to push and offers to set the upstream.
+func (m *Model) PreparePush() (*Model, tea.Cmd) {
+	if !m.loadCurrentTracking() {
+		return m, m.finish()
+	}
+
+	if m.BranchModel.Upstream != "" {
+		m.BranchModel.SelectedBranch = "Push to " + m.BranchModel.Upstream
+		m.Level = 3
+
+		remote, branch, err := git.GetUpstreamRef(m.BranchModel.Current)
+		if err != nil {
+			m.Err = fmt.Sprintf("%v", err)
+			return m, m.finish()
+		}
+		// the upstream of a branch created from another local branch is that branch, not a remote
+		if remote == "." {
+			m.Err = fmt.Sprintf("'%s' tracks the local branch '%s', there is no remote to push to", m.BranchModel.Current, branch)
+			return m, m.finish()
+		}
+
+		out, err := git.PushToUpstream(remote, branch)
+		m.OutputByLevel(out)
+		if err != nil {
+			m.Err = "Failed to Push Branch"
+		} else {
+			m.Success = fmt.Sprintf("Pushed '%s' to %s", m.BranchModel.Current, m.BranchModel.Upstream)
+		}
+		return m, m.finish()
+	}
+
+	remotes, errOut := git.GetRemotesClean()
+	remotes = strings.TrimSpace(remotes)
+	if errOut != "" || remotes == "" {
+		m.Err = "No remotes to push to, add one first"
+		return m, m.finish()
+	}
+
+	m.BranchModel.Remotes = strings.Split(remotes, "\n")
+	var options []string
+	for _, remote := range m.BranchModel.Remotes {
+		options = append(options, fmt.Sprintf("Push to %s/%s and track it", remote, m.BranchModel.Current))
+	}
+	for _, remote := range m.BranchModel.Remotes {
+		options = append(options, fmt.Sprintf("Push to %s/%s without tracking", remote, m.BranchModel.Current))
+	}
+
+	m.BranchModel.Branches = options
+	m.Selected = 0
+	m.CurrentStep = StepBranchSelect
+	m.Level = 3
+	return m, nil
+}
+
+// ExecutePush pushes the current branch to the chosen remote, see PreparePush
+func (m *Model) ExecutePush() (*Model, tea.Cmd) {
+	current := m.BranchModel.Current
+	// the options to track come first, then the same remotes without tracking
+	index := m.Selected
+	track := index < len(m.BranchModel.Remotes)
+	remote := m.BranchModel.Remotes[index%len(m.BranchModel.Remotes)]
+
+	var out string
+	var err error
+	if track {
+		out, err = git.PushBranch(remote, current)
+	} else {
+		out, err = git.PushBranchUntracked(remote, current)
+	}
+
+	m.OutputByLevel(out)
+	switch {
+	case err != nil:
+		m.Err = "Failed to Push Branch"
+	case track:
+		m.Success = fmt.Sprintf("Pushed '%s' to %s/%s and set it as upstream", current, remote, current)
+	default:
+		m.Success = fmt.Sprintf("Pushed '%s' to %s/%s", current, remote, current)
+	}
+	return m, m.finish()
+}
@@ -25,6 +25,13 @@ var (
 	YellowStyle    lipgloss.Style
 	PeachStyle	   lipgloss.Style
 	RedStyle       lipgloss.Style
+
+	// highlight the changed words of a line in the diff pane
+	GreenHighlightStyle lipgloss.Style
+	RedHighlightStyle   lipgloss.Style
+
+	// colors of the lanes of the commit graph
+	LaneStyles []lipgloss.Style
 )
 
 func UpdateStylesByConfig(cfg *config.Config) {
@@ -87,6 +94,19 @@ func UpdateStyles(flavor catppuccingo.Flavor, accentColor catppuccingo.Color) {
 
 	RedStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color(flavor.Red().Hex))
+
+	GreenHighlightStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(flavor.Base().Hex)).
+		Background(lipgloss.Color(flavor.Green().Hex))
+
+	RedHighlightStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(flavor.Base().Hex)).
+		Background(lipgloss.Color(flavor.Red().Hex))
+
+	LaneStyles = nil
+	for _, color := range []catppuccingo.Color{flavor.Blue(), flavor.Mauve(), flavor.Peach(), flavor.Green(), flavor.Pink(), flavor.Teal(), flavor.Yellow(), flavor.Sky()} {
+		LaneStyles = append(LaneStyles, lipgloss.NewStyle().Foreground(lipgloss.Color(color.Hex)))
+	}
 }
 
 // InitializeStyles initializes styles with default or loaded config
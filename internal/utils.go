@@ -383,6 +383,8 @@ func PrintHelp() {
 
 Usage:
   gith                   Start interactive mode
+  gith -i                Start a persistent session, return to the menu after each action
+  gith ... --yes         Skip confirmations of destructive operations (combine with any command)
 
   gith version           Show version information  
   gith version check     Show version & check for updates
@@ -393,7 +395,8 @@ Usage:
   gith push tag          Push Tag
   gith list tag          List 10 latest tags
 
-  gith switch            Switch Branch
+  gith switch            Switch Branch, the most recently used first
+  gith switch -          Switch back to the previous branch
   gith delete branch     Delete Branch
   gith list branch       List Branches
 
@@ -409,11 +412,25 @@ Usage:
   gith config help       Show config related help message
   gith help              Show this help message
 
-Interactive Commands:
+Interactive Commands (default keymap, see 'gith config help'):
   ↑↓ or j/k              Navigate menu items
+  PgUp/PgDn              Jump a page up / down
+  Home/End or g/G        Jump to the first / last item
   Enter                  Select item
+  /                      Fuzzy filter the current list (esc clears the filter)
+  Shift+↑↓               Scroll long output
+  Space                  Mark several entries (Delete Branch, Prune, Remove Tag)
+  -                      Switch back to the previous branch (Switch Branch)
+  ←→ or h/l              Previous / next file of a diff
+  p                      Blame the parent of the selected line's commit (Blame)
   Ctrl+H, Ctrl+Y         Go back to previous step
-  Q/Esc                  Quit application
+  Q/Esc                  Quit application (the only way to leave a persistent session)
+
+Text Inputs:
+  ←→, Home/End           Move the cursor
+  Ctrl+W, Ctrl+U         Delete word / everything before the cursor
+  Ctrl+V                 Paste (bracketed paste works as well)
+  Esc                    Quit application
 
 Features:
   • Branch management
@@ -437,10 +454,13 @@ func GenerateCompletions(shell string) error {
 complete -c gith -f
 complete -c gith -n "__fish_use_subcommand" -a "version update config help add push tag status undo commit switch" -d "Available commands"
 complete -c gith -n "__fish_seen_subcommand_from version" -a "check" -d "Check for updates"
-complete -c gith -n "__fish_seen_subcommand_from config" -a "show reset path update help" -d "Config commands"
+complete -c gith -n "__fish_seen_subcommand_from config" -a "show reset path update validate help" -d "Config commands"
 complete -c gith -n "__fish_seen_subcommand_from config update" -l flavor -d "Catppuccin flavor" -a "latte frappe macchiato mocha"
 complete -c gith -n "__fish_seen_subcommand_from config update" -l accent -d "Catppuccin accent" -a "rosewater flamingo pink mauve red maroon peach yellow green teal sky sapphire blue lavender gray"
 complete -c gith -n "__fish_seen_subcommand_from config update" -l initFetch -d "Init fetch behaviour" -a "always quick never"
+complete -c gith -n "__fish_seen_subcommand_from config update" -l persistent -d "Persistent session mode" -a "true false"
+complete -c gith -n "__fish_seen_subcommand_from config update" -l skipConfirm -d "Skip confirmations" -a "true false"
+complete -c gith -n "__fish_seen_subcommand_from config update" -l ticketPlacement -d "Ticket ID placement in commits" -a "scope prefix footer off"
 complete -c gith -n "__fish_seen_subcommand_from add" -a "remote" -d "Quick Select: Add Remote"
 complete -c gith -n "__fish_seen_subcommand_from push" -a "tag" -d "Quick Select: Push Tag"
 complete -c gith -n "__fish_seen_subcommand_from undo" -a "commit" -d "Quick Select: Status"
@@ -467,7 +487,7 @@ _gith() {
             return 0
             ;;
         config)
-            opts="show reset path update help"
+            opts="show reset path update validate help"
             COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
             return 0
             ;;
@@ -511,6 +531,16 @@ _gith() {
             COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
             return 0
             ;;
+        --persistent|--skipConfirm)
+            opts="true false"
+            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+            return 0
+            ;;
+        --ticketPlacement)
+            opts="scope prefix footer off"
+            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+            return 0
+            ;;
     esac
 }
 complete -F _gith gith
@@ -531,10 +561,13 @@ _gith() {
                     ;;
                 config)
                     _arguments \
-                        '1:subcommand:(show reset path update help tag)' \
+                        '1:subcommand:(show reset path update validate help tag)' \
                         '--flavor[Catppuccin flavor]:(latte frappe macchiato mocha)' \
                         '--accent[Catppuccin accent]:(rosewater flamingo pink mauve red maroon peach yellow green teal sky sapphire blue lavender)' \
-						'--initFetch[Init fetch behaviour]:(always quick never)'
+						'--initFetch[Init fetch behaviour]:(always quick never)' \
+						'--persistent[Persistent session mode]:(true false)' \
+						'--skipConfirm[Skip confirmations]:(true false)' \
+						'--ticketPlacement[Ticket ID placement in commits]:(scope prefix footer off)'
                     ;;
                 add)
                     _arguments '1:subcommand:(remote)'
@@ -571,7 +604,7 @@ func IsGitRepository() (bool, error) {
 // isInputStep returns true if the current step expects free-text input
 func isInputStep(step Step) bool {
 	switch step {
-	case StepTagInput, StepBranchInput, StepRemoteNameInput, StepRemoteUrlInput, StepCommitInput:
+	case StepTagInput, StepBranchInput, StepBranchTemplateInput, StepBranchBaseInput, StepRemoteNameInput, StepRemoteUrlInput, StepCommitInput, StepChangesRangeInput, StepFileHistoryInput:
 		return true
 	default:
 		return false
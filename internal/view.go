@@ -40,6 +40,7 @@ func (m Model) renderMainView() string {
 	line := ui.LineStyle.Render("│")
 
 	content.WriteString(m.renderHeader())
+	content.WriteString(m.renderLastResult())
 	content.WriteString(m.renderOutput(line, 0)) // For initial/global output
 
 	if m.StartAt == "" || m.StartAtLevel <= 1 {
@@ -58,7 +59,12 @@ func (m Model) renderMainView() string {
 			content.WriteString(m.renderOutput(line, 3)) // Output for level 3
 		}
 
-		if isInputStep(m.CurrentStep) { // TODO: check if for all output this many levels
+		content.WriteString(m.renderSubActions3())
+
+		if m.ConfirmModel.Title != "" {
+			content.WriteString(m.renderConfirm())
+			content.WriteString(m.renderOutput(line, 4)) // Output for level 4
+		} else if isInputStep(m.CurrentStep) && m.Level == 4 { // TODO: check if for all output this many levels
 			content.WriteString(m.renderOutput(line, 4)) // Output for level 4
 		}
 	}
@@ -72,7 +78,73 @@ func (m Model) renderMainView() string {
 
 // renderHeader returns the application title bar.
 func (m Model) renderHeader() string {
-	return ui.LineStyle.Render("╭─╌") + " " + ui.AccentStyle.Render("gith") + "\n"
+	return ui.LineStyle.Render("╭─╌") + " " + ui.AccentStyle.Render("gith") + "\n" + m.renderRepoInfo()
+}
+
+// renderRepoInfo renders the repository state below the title,
+// e.g. "main → origin/main ↑1 · 2 staged 1 modified · 1 stash · v1.2.0"
+func (m Model) renderRepoInfo() string {
+	line := ui.LineStyle.Render("│")
+	if m.RepoInfo.Err != "" {
+		return line + " " + ui.ErrorStyle.Render(m.RepoInfo.Err) + "\n"
+	}
+	if m.RepoInfo.Loading {
+		return line + " " + ui.DimStyle.Render("Loading repository state...") + "\n"
+	}
+
+	info := m.RepoInfo.Info
+	var parts []string
+
+	// branch, upstream and ahead / behind
+	head := ui.AccentStyle.Render(info.Branch)
+	if info.Branch == "" {
+		head = ui.PeachStyle.Render("detached at " + info.Head)
+	}
+	if info.Upstream != "" {
+		head += ui.DimStyle.Render(" → " + info.Upstream)
+		if info.Ahead > 0 {
+			head += " " + ui.GreenStyle.Render(fmt.Sprintf("↑%d", info.Ahead))
+		}
+		if info.Behind > 0 {
+			head += " " + ui.RedStyle.Render(fmt.Sprintf("↓%d", info.Behind))
+		}
+	}
+	parts = append(parts, head)
+
+	if info.Operation != "" {
+		parts = append(parts, ui.RedStyle.Render(info.Operation))
+	}
+
+	// working tree
+	var changes []string
+	if info.Conflicts > 0 {
+		changes = append(changes, ui.RedStyle.Render(fmt.Sprintf("%d conflicts", info.Conflicts)))
+	}
+	if info.Staged > 0 {
+		changes = append(changes, ui.GreenStyle.Render(fmt.Sprintf("%d staged", info.Staged)))
+	}
+	if info.Unstaged > 0 {
+		changes = append(changes, ui.YellowStyle.Render(fmt.Sprintf("%d modified", info.Unstaged)))
+	}
+	if info.Untracked > 0 {
+		changes = append(changes, ui.DimStyle.Render(fmt.Sprintf("%d untracked", info.Untracked)))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, ui.DimStyle.Render("clean"))
+	}
+	parts = append(parts, strings.Join(changes, " "))
+
+	if info.Stashes == 1 {
+		parts = append(parts, ui.TextStyle.Render("1 stash"))
+	} else if info.Stashes > 1 {
+		parts = append(parts, ui.TextStyle.Render(fmt.Sprintf("%d stashes", info.Stashes)))
+	}
+
+	if info.LatestTag != "" {
+		parts = append(parts, ui.TextStyle.Render(info.LatestTag))
+	}
+
+	return line + " " + strings.Join(parts, ui.DimStyle.Render(" · ")) + "\n"
 }
 
 // renderActionSelection renders the primary action choices (Branch, Commit, etc.).
@@ -107,12 +179,31 @@ func (m Model) renderSubActions() string {
 		return m.renderTagActions()
 	case "Remote":
 		return m.renderRemoteActions()
+	case "Changes":
+		return m.renderChangesActions()
+	case "History":
+		return m.renderHistoryActions()
 	case "Options":
 		return m.renderOptionsActions()
 	}
 	return ""
 }
 
+// renderSubActions3 renders the fourth level of flows that have one, e.g. the new name of Rename Branch
+func (m Model) renderSubActions3() string {
+	switch m.ActionModel.SelectedAction {
+	case "Branch":
+		return m.renderBranchSubActions3()
+	case "Changes":
+		if m.CurrentStep == StepDiff && m.Level == 4 {
+			return m.renderDiffPane()
+		}
+	case "History":
+		return m.renderHistorySubActions3()
+	}
+	return ""
+}
+
 // renderSubActions2 delegates rendering of the third-level action lists and confirmations.
 func (m Model) renderSubActions2() string {
 	switch m.ActionModel.SelectedAction {
@@ -124,6 +215,10 @@ func (m Model) renderSubActions2() string {
 		return m.renderTagSubActions2()
 	case "Remote":
 		return m.renderRemoteSubActions2()
+	case "Changes":
+		return m.renderChangesSubActions2()
+	case "History":
+		return m.renderHistorySubActions2()
 	case "Options":
 		return m.renderOptionsSubActions2()
 	}
@@ -155,8 +250,12 @@ func (m Model) renderBranchSubActions2() string {
 	bullet := m.getBullet(3)
 
 	switch m.BranchModel.SelectedAction {
-	case "Switch Branch", "Delete Branch":
-		content.WriteString(bullet + " " + ui.TextStyle.Render(m.BranchModel.SelectedAction) + "\n")
+	case "Switch Branch", "Delete Branch", "Prune Stale Branches", "Clean Up Merged":
+		title := m.BranchModel.SelectedAction
+		if m.BranchModel.BaseBranch != "" {
+			title += ui.DimStyle.Render(" into " + m.BranchModel.BaseBranch)
+		}
+		content.WriteString(bullet + " " + ui.TextStyle.Render(title) + "\n")
 
 		// If no branch is selected yet, show the list of branches to choose from.
 		if m.BranchModel.SelectedBranch == "" {
@@ -167,12 +266,99 @@ func (m Model) renderBranchSubActions2() string {
 		} else { // A branch has been selected, show it as completed.
 			content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedBranch) + "\n")
 		}
+	case "Tracking", "Push Branch":
+		if m.Level < 3 {
+			// e.g. failed on a detached HEAD
+			break
+		}
+
+		title := m.BranchModel.SelectedAction + ui.DimStyle.Render(" of "+m.BranchModel.Current)
+		if m.BranchModel.Upstream != "" {
+			title += ui.DimStyle.Render(" → " + m.BranchModel.Upstream)
+		} else {
+			title += ui.DimStyle.Render(", no upstream")
+		}
+		content.WriteString(bullet + " " + ui.TextStyle.Render(title) + "\n")
+
+		if m.BranchModel.SelectedBranch == "" {
+			if len(m.BranchModel.Branches) > 0 && m.Err == "" {
+				content.WriteString(m.renderOptions(m.BranchModel.Branches, m.CurrentStep == StepBranchSelect))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedBranch) + "\n")
+	case "Compare":
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Compare") + "\n")
+
+		if m.CompareModel.Left == "" {
+			if len(m.CompareModel.LeftRefs) > 0 && m.Err == "" {
+				content.WriteString(m.renderOptions(m.CompareModel.LeftRefs, m.CurrentStep == StepCompareLeft))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.CompareModel.Left) + "\n")
+	case "Rename Branch":
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Rename Branch") + "\n")
+
+		if m.BranchModel.SelectedBranch == "" {
+			if len(m.BranchModel.Branches) > 0 && m.Err == "" {
+				content.WriteString(m.renderOptions(m.BranchModel.Branches, m.CurrentStep == StepBranchSelect))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			break
+		}
+
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedBranch) + "\n")
 	case "Create Branch":
 		content.WriteString(bullet + " " + ui.TextStyle.Render("Create Branch") + "\n")
 
 		if m.BranchModel.SelectedOption == "" {
-			// Show add options (feat, fix, refactor, ..., manual)
-			if len(m.BranchModel.Options) > 0 && m.Err == "" {
+			// Show add options (templates, feat, fix, refactor, ..., manual)
+			if m.Err == "" {
+				content.WriteString(m.renderOptions(m.branchNameOptions(), m.CurrentStep == StepBranchCreate))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+		} else if m.CurrentStep == StepBranchTemplateInput {
+			// Show the fields entered so far and the input of the next one
+			if m.Err == "" {
+				content.WriteString(m.renderTemplateInput())
+			}
+		} else if m.CurrentStep == StepBranchInput {
+			// Show input field
+			if m.Err == "" {
+				content.WriteString(m.renderBranchInput())
+			}
+		} else {
+			// Show completed name
+			content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.Input) + "\n")
+		}
+	}
+
+	return content.String()
+}
+
+// renderBranchSubActions3 renders the fourth level of branch actions, e.g. the new name of Rename Branch.
+func (m Model) renderBranchSubActions3() string {
+	var content strings.Builder
+	bullet := m.getBullet(4)
+
+	switch m.BranchModel.SelectedAction {
+	case "Rename Branch":
+		if m.BranchModel.SelectedBranch == "" {
+			break
+		}
+
+		if m.CurrentStep == StepConfirm {
+			// the confirmation to rename the remote branch is on the same level
+			bullet = ui.BulletStyle.Render("◇")
+		}
+		content.WriteString(bullet + " " + ui.TextStyle.Render("New name") + "\n")
+
+		if m.BranchModel.SelectedOption == "" {
+			// Show prefix options (feat, fix, ..., manual)
+			if m.Err == "" {
 				content.WriteString(m.renderOptions(m.BranchModel.Options, m.CurrentStep == StepBranchCreate))
 				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
 			}
@@ -182,10 +368,460 @@ func (m Model) renderBranchSubActions2() string {
 				content.WriteString(m.renderBranchInput())
 			}
 		} else {
-			// Show completed selection
-			content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedOption) + "\n")
+			// Show completed name, e.g. above the confirmation to rename the remote branch
+			content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.Input) + "\n")
+			content.WriteString(ui.LineStyle.Render("│") + "\n")
+		}
+	case "Create Branch":
+		content.WriteString(m.renderBranchBase())
+	case "Compare":
+		content.WriteString(m.renderCompare())
+	}
+
+	return content.String()
+}
+
+// renderBranchBase renders where a new branch starts (level 4), the branch, tag or commit
+// for it (level 5) and whether to switch to the new branch (the level below)
+func (m Model) renderBranchBase() string {
+	var content strings.Builder
+	line := ui.LineStyle.Render("│")
+
+	if m.BranchModel.Input == "" || m.Level < 4 {
+		return ""
+	}
+
+	content.WriteString(m.getBullet(4) + " " + ui.TextStyle.Render("Start from") + "\n")
+	if m.BranchModel.SelectedBase == "" {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.BranchModel.Bases, m.CurrentStep == StepBranchBase))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+		return content.String()
+	}
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedBase) + "\n")
+	content.WriteString(m.renderOutput(line, 4))
+
+	switchLevel := 5
+	switch m.BranchModel.SelectedBase {
+	case "Branch", "Tag":
+		switchLevel = 6
+		content.WriteString(m.getBullet(5) + " " + ui.TextStyle.Render("Select "+strings.ToLower(m.BranchModel.SelectedBase)) + "\n")
+		if m.CurrentStep == StepBranchBaseSelect {
+			if m.Err == "" {
+				content.WriteString(m.renderOptions(m.BranchModel.BaseRefs, true))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			return content.String()
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.BaseBranch) + "\n")
+		content.WriteString(m.renderOutput(line, 5))
+	case "Commit":
+		switchLevel = 6
+		content.WriteString(m.getBullet(5) + " " + ui.TextStyle.Render("Commit") + "\n")
+		if m.CurrentStep == StepBranchBaseInput {
+			if m.Err == "" {
+				content.WriteString(m.renderInput("Enter commit:", m.BranchModel.BaseInput))
+			}
+			return content.String()
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.BaseBranch) + "\n")
+		content.WriteString(m.renderOutput(line, 5))
+	}
+
+	if m.Level < switchLevel {
+		return content.String()
+	}
+
+	content.WriteString(m.getBullet(switchLevel) + " " + ui.TextStyle.Render("Switch to the new branch?") + "\n")
+	if m.BranchModel.SelectedSwitch == "" {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.BranchModel.SwitchOptions, m.CurrentStep == StepBranchSwitch))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+		return content.String()
+	}
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BranchModel.SelectedSwitch) + "\n")
+	content.WriteString(m.renderOutput(line, switchLevel))
+
+	return content.String()
+}
+
+// renderCompare renders the ref to compare with (level 4) with the commits unique to each side,
+// the changed files (level 5) and the diff pane at the selected file (level 6)
+func (m Model) renderCompare() string {
+	var content strings.Builder
+	line := ui.LineStyle.Render("│")
+
+	if m.CompareModel.Left == "" || m.Level < 4 {
+		return ""
+	}
+
+	content.WriteString(m.getBullet(4) + " " + ui.TextStyle.Render("with") + "\n")
+	if m.CompareModel.Right == "" {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.CompareModel.RightRefs, m.CurrentStep == StepCompareRight))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+		return content.String()
+	}
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.CompareModel.Right) + "\n")
+	if m.Level <= 5 && len(m.Output) > 4 {
+		// the summary stays visible while selecting a file, the diff gets the space
+		content.WriteString(m.renderOutputText(line, m.Output[4]))
+	} else {
+		content.WriteString(line + "\n")
+	}
+
+	if m.Level < 5 {
+		return content.String()
+	}
+
+	title := "Files changed" + ui.DimStyle.Render(" on "+m.CompareModel.Left+" since the merge base")
+	content.WriteString(m.getBullet(5) + " " + ui.TextStyle.Render(title) + "\n")
+	if m.CompareModel.SelectedFile == "" {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.CompareModel.FileOptions, m.CurrentStep == StepCompareFiles))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+		return content.String()
+	}
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.CompareModel.SelectedFile) + "\n")
+	if m.CurrentStep == StepDiff {
+		content.WriteString(line + "\n")
+		content.WriteString(m.renderDiffPane())
+	}
+
+	return content.String()
+}
+
+// renderChangesActions renders what kind of changes to show
+func (m Model) renderChangesActions() string {
+	var content strings.Builder
+	bullet := m.getBullet(2)
+
+	content.WriteString(bullet + " " + ui.TextStyle.Render("Show changes") + "\n")
+
+	if m.ChangesModel.SelectedAction == "" && len(m.ChangesModel.Actions) > 0 {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.ChangesModel.Actions, m.CurrentStep == StepChanges))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+	} else if m.ChangesModel.SelectedAction != "" {
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.ChangesModel.SelectedAction) + "\n")
+	}
+
+	return content.String()
+}
+
+// renderChangesSubActions2 renders the commit or range to show (level 3),
+// or the diff pane right away for the unstaged and staged changes
+func (m Model) renderChangesSubActions2() string {
+	var content strings.Builder
+	bullet := m.getBullet(3)
+
+	switch m.ChangesModel.SelectedAction {
+	case "Commit":
+		if m.Level < 3 {
+			break
+		}
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Select commit") + "\n")
+		if m.ChangesModel.SelectedCommit == "" {
+			if m.Err == "" {
+				content.WriteString(m.renderOptions(m.ChangesModel.Commits, m.CurrentStep == StepChangesCommit))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.ChangesModel.SelectedCommit) + "\n")
+	case "Range":
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Range") + "\n")
+		if m.CurrentStep == StepChangesRangeInput {
+			if m.Err == "" {
+				content.WriteString(m.renderInput("Enter a range, or a revision to compare with the working tree:", m.ChangesModel.RangeInput))
+			}
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.ChangesModel.RangeInput) + "\n")
+	}
+
+	if m.CurrentStep == StepDiff && m.Level == 3 {
+		content.WriteString(m.renderDiffPane())
+	}
+
+	return content.String()
+}
+
+// renderHistoryActions renders the list of history views
+func (m Model) renderHistoryActions() string {
+	var content strings.Builder
+	bullet := m.getBullet(2)
+
+	content.WriteString(bullet + " " + ui.TextStyle.Render("Select history view") + "\n")
+
+	if m.HistoryModel.SelectedAction == "" && len(m.HistoryModel.Actions) > 0 {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.HistoryModel.Actions, m.CurrentStep == StepHistory))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+	} else if m.HistoryModel.SelectedAction != "" {
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.HistoryModel.SelectedAction) + "\n")
+	}
+
+	return content.String()
+}
+
+// renderHistorySubActions2 renders the history view, e.g. the commit graph
+func (m Model) renderHistorySubActions2() string {
+	var content strings.Builder
+	bullet := m.getBullet(3)
+
+	switch m.HistoryModel.SelectedAction {
+	case "Graph":
+		if len(m.GraphModel.Lines) == 0 {
+			break
+		}
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Graph") + ui.DimStyle.Render(" of all branches") + "\n")
+		if m.CurrentStep == StepGraph {
+			content.WriteString(m.renderGraph())
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.HistoryModel.SelectedCommit) + "\n")
+	case "Blame":
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Select file") + "\n")
+		if m.BlameModel.File == "" {
+			if m.Err == "" {
+				content.WriteString(m.renderOptions(m.BlameModel.Files, m.CurrentStep == StepBlameFile))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+			break
+		}
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.BlameModel.File) + "\n")
+	case "File History":
+		content.WriteString(bullet + " " + ui.TextStyle.Render("Select file") + "\n")
+		switch {
+		case m.CurrentStep == StepFileHistoryPath:
+			if m.Err == "" {
+				content.WriteString(m.renderOptions(m.FileHistoryModel.Paths, true))
+				content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+			}
+		case m.CurrentStep == StepFileHistoryInput:
+			if m.Err == "" {
+				content.WriteString(m.renderInput("Enter path (tab completes):", m.FileHistoryModel.PathInput))
+			}
+		case m.FileHistoryModel.Path != "":
+			content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.FileHistoryModel.Path) + "\n")
+		}
+	}
+
+	return content.String()
+}
+
+// renderHistorySubActions3 renders the levels below the history view, e.g. the blame of the selected file
+func (m Model) renderHistorySubActions3() string {
+	switch m.HistoryModel.SelectedAction {
+	case "Graph":
+		return m.renderCommitDetails(4)
+	case "Blame":
+		return m.renderBlame() + m.renderCommitDetails(5)
+	case "File History":
+		return m.renderFileHistory()
+	}
+	return ""
+}
+
+// renderFileHistory renders the commits that changed the file (level 4) and the diff of the selected one
+func (m Model) renderFileHistory() string {
+	var content strings.Builder
+
+	if len(m.FileHistoryModel.Commits) == 0 || m.Level < 4 {
+		return ""
+	}
+
+	content.WriteString(m.getBullet(4) + " " + ui.TextStyle.Render("Commits") + ui.DimStyle.Render(" that changed "+m.FileHistoryModel.Path) + "\n")
+	if m.FileHistoryModel.SelectedCommit == "" {
+		if m.Err == "" {
+			content.WriteString(m.renderOptions(m.FileHistoryModel.Options, m.CurrentStep == StepFileHistory))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+		return content.String()
+	}
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.FileHistoryModel.SelectedCommit) + "\n")
+
+	if m.CurrentStep == StepDiff {
+		content.WriteString(ui.LineStyle.Render("│") + "\n")
+		content.WriteString(m.renderDiffPane())
+	}
+	return content.String()
+}
+
+// renderBlame renders the blamed lines around the selected one (level 4). Each commit gets a color,
+// its SHA, author and date are shown on the first line of each group of lines from it.
+func (m Model) renderBlame() string {
+	var content strings.Builder
+	line := ui.AccentStyle.Render("│")
+	lines := m.BlameModel.Lines
+
+	if len(lines) == 0 || m.Level < 4 {
+		return ""
+	}
+
+	at := " of the working tree"
+	if rev := m.BlameModel.Rev; rev != "" {
+		at = " at " + rev[:min(len(rev), 7)]
+	}
+	content.WriteString(m.getBullet(4) + " " + ui.TextStyle.Render("Blame "+m.BlameModel.File) + ui.DimStyle.Render(at) + "\n")
+
+	if m.CurrentStep != StepBlame {
+		selected := lines[m.BlameModel.Selected]
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(fmt.Sprintf("line %d: %s", m.BlameModel.Selected+1, strings.TrimSpace(selected.Text))) + "\n")
+		if m.Level > 4 {
+			content.WriteString(ui.LineStyle.Render("│") + "\n")
+		}
+		return content.String()
+	}
+
+	width := 0
+	if m.Width > 0 {
+		width = max(m.Width-8, 20)
+	}
+	colors := m.blameColors()
+	numberWidth := len(fmt.Sprint(len(lines)))
+
+	start, end := scrollWindow(len(lines), m.listHeight(), m.BlameModel.Selected)
+	content.WriteString(renderScrollIndicator(line, "↑", start, ""))
+	for i := start; i < end; i++ {
+		blameLine := lines[i]
+		lane := ui.LaneStyles[colors[blameLine.Sha]%len(ui.LaneStyles)]
+
+		bar := lane.Render("▌")
+		textStyle := ui.NormalStyle
+		if i == m.BlameModel.Selected {
+			bar = ui.BulletStyle.Render("●")
+			textStyle = ui.SelectedStyle
+		}
+
+		// the commit is shown on the first line of each group and the first visible line
+		meta := strings.Repeat(" ", 7+1+14+1+14)
+		if i == start || lines[i-1].Sha != blameLine.Sha {
+			author := blameLine.Author
+			if len([]rune(author)) > 14 {
+				author = string([]rune(author)[:13]) + "…"
+			}
+			meta = lane.Render(blameLine.Sha) + " " + ui.NormalStyle.Render(fmt.Sprintf("%-14s", author)) + " " + ui.DimStyle.Render(fmt.Sprintf("%-14s", blameLine.Date))
+		}
+
+		number := ui.DimStyle.Render(fmt.Sprintf("%*d", numberWidth, i+1))
+		row := bar + " " + meta + " " + number + " " + textStyle.Render(strings.ReplaceAll(blameLine.Text, "\t", "    "))
+		if width > 0 {
+			row = lipgloss.NewStyle().MaxWidth(width).Render(row)
 		}
+		content.WriteString(line + " " + row + "\n")
 	}
+	content.WriteString(renderScrollIndicator(line, "↓", len(lines)-end, fmt.Sprintf("%d of %d", m.BlameModel.Selected+1, len(lines))))
+	content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+
+	return content.String()
+}
+
+// renderCommitDetails renders the details of the selected commit at a level, see ShowCommitDetails
+func (m Model) renderCommitDetails(level int) string {
+	if m.HistoryModel.SelectedCommit == "" || m.Level < level {
+		return ""
+	}
+
+	title := "Commit " + branchName(m.HistoryModel.SelectedCommit)
+	return m.getBullet(level) + " " + ui.TextStyle.Render(title) + "\n" + m.renderOutput(ui.LineStyle.Render("│"), level)
+}
+
+// renderGraph renders the loaded part of the commit graph around the selected commit,
+// the lanes are colored by their column
+func (m Model) renderGraph() string {
+	var content strings.Builder
+	line := ui.AccentStyle.Render("│")
+	lines := m.GraphModel.Lines
+
+	start, end := scrollWindow(len(lines), m.listHeight(), m.GraphModel.Selected)
+	content.WriteString(renderScrollIndicator(line, "↑", start, ""))
+
+	width := 0
+	if m.Width > 0 {
+		width = max(m.Width-8, 20)
+	}
+
+	for i := start; i < end; i++ {
+		graphLine := lines[i]
+		selected := i == m.GraphModel.Selected
+
+		var row strings.Builder
+		for column, char := range graphLine.Graph {
+			lane := ui.LaneStyles[column/2%len(ui.LaneStyles)]
+			switch {
+			case char == '*' && selected:
+				row.WriteString(ui.BulletStyle.Render("●"))
+			case char == '*':
+				row.WriteString(lane.Render("○"))
+			default:
+				row.WriteString(lane.Render(string(char)))
+			}
+		}
+
+		if graphLine.Sha != "" {
+			subjectStyle := ui.NormalStyle
+			if selected {
+				subjectStyle = ui.SelectedStyle
+			}
+			row.WriteString(" " + ui.YellowStyle.Render(graphLine.Sha))
+			if graphLine.Refs != "" {
+				row.WriteString(" " + ui.AccentStyle.Render("("+graphLine.Refs+")"))
+			}
+			row.WriteString(" " + subjectStyle.Render(graphLine.Subject))
+			row.WriteString(" " + ui.DimStyle.Render(graphLine.Date))
+		}
+
+		rendered := row.String()
+		if width > 0 {
+			rendered = lipgloss.NewStyle().MaxWidth(width).Render(rendered)
+		}
+		content.WriteString(line + " " + rendered + "\n")
+	}
+
+	content.WriteString(renderScrollIndicator(line, "↓", len(lines)-end, m.graphPosition()))
+	content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+	return content.String()
+}
+
+// renderDiffPane renders the current file of the diff pane, scrolled to fit the terminal
+func (m Model) renderDiffPane() string {
+	var content strings.Builder
+	line := ui.AccentStyle.Render("│")
+	file := m.DiffModel.Files[m.DiffModel.File]
+
+	title := ui.TextStyle.Render(file.Path) +
+		ui.GreenStyle.Render(fmt.Sprintf(" +%d", file.Additions)) +
+		ui.RedStyle.Render(fmt.Sprintf(" -%d", file.Deletions)) +
+		ui.DimStyle.Render(fmt.Sprintf("  file %d of %d", m.DiffModel.File+1, len(m.DiffModel.Files)))
+	content.WriteString(m.getBullet(m.Level) + " " + title + "\n")
+
+	// the container padding and the line on the left
+	width := 0
+	if m.Width > 0 {
+		width = max(m.Width-8, 20)
+	}
+	lines := renderDiffLines(file.Lines, width)
+
+	start, end := 0, len(lines)
+	if height := m.diffHeight(); height > 0 && len(lines) > height {
+		start = min(m.DiffModel.Scroll, len(lines)-height)
+		end = start + height
+	}
+
+	content.WriteString(renderScrollIndicator(line, "↑", start, ""))
+	for _, diffLine := range lines[start:end] {
+		content.WriteString(line + " " + diffLine + "\n")
+	}
+	content.WriteString(renderScrollIndicator(line, "↓", len(lines)-end, ""))
+	content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
 
 	return content.String()
 }
@@ -323,7 +959,7 @@ func (m Model) renderRemoteSubActions2() string {
 		if m.CurrentStep == StepRemoteNameInput {
 			// Show input field
 			if m.Err == "" {
-				content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.AccentStyle.Render("Remote Name:") + " " + ui.DimStyle.Render("(e.g. origin)") + "\n")
+				content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.AccentStyle.Render("Remote Name:") + "\n")
 				content.WriteString(m.renderRemoteInput())
 			}
 		} else {
@@ -335,7 +971,7 @@ func (m Model) renderRemoteSubActions2() string {
 
 				// Show input field
 				if m.Err == "" {
-					content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.AccentStyle.Render("Remote Url:") + " " + ui.DimStyle.Render("(e.g. git@github.com:a3chron/gith.git)") + "\n")
+					content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.AccentStyle.Render("Remote Url:") + "\n")
 					content.WriteString(m.renderRemoteInput())
 				}
 			} else {
@@ -442,6 +1078,30 @@ func (m Model) renderOptionsSubActions2() string {
 	return content.String()
 }
 
+// renderConfirm renders the confirmation step with everything that will happen
+func (m Model) renderConfirm() string {
+	var content strings.Builder
+	bullet := m.getBullet(4)
+
+	content.WriteString(bullet + " " + ui.TextStyle.Render(m.ConfirmModel.Title) + "\n")
+
+	if m.ConfirmModel.Answer == "" {
+		if m.Err == "" {
+			accLine := ui.AccentStyle.Render("│")
+			for _, l := range m.ConfirmModel.Lines {
+				content.WriteString(accLine + "   " + colorize(l, ui.NormalStyle) + "\n")
+			}
+			content.WriteString(accLine + "\n")
+			content.WriteString(m.renderOptions(m.ConfirmModel.Options, m.CurrentStep == StepConfirm))
+			content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
+		}
+	} else {
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.CompletedStyle.Render(m.ConfirmModel.Answer) + "\n")
+	}
+
+	return content.String()
+}
+
 // Update renderOptionsWithPreview with safety checks:
 func (m Model) renderOptionsWithPreview(options []string, isCurrentStep bool, optionType string) string {
 	var content strings.Builder
@@ -452,9 +1112,19 @@ func (m Model) renderOptionsWithPreview(options []string, isCurrentStep bool, op
 		return content.String()
 	}
 
-	for i, option := range options {
+	entries := m.optionEntries(options, isCurrentStep)
+	if isCurrentStep && m.Filter.Active {
+		content.WriteString(m.renderFilterLine(len(entries), len(options)))
+	}
+
+	start, end := m.optionWindow(len(entries), isCurrentStep)
+	content.WriteString(renderScrollIndicator(accLine, "↑", start, ""))
+
+	for i := start; i < end; i++ {
+		entry := entries[i]
 		var bullet string
 		var preview string
+		option := options[entry.Index]
 
 		// Create a preview of what the option would look like
 		switch optionType {
@@ -470,13 +1140,15 @@ func (m Model) renderOptionsWithPreview(options []string, isCurrentStep bool, op
 
 		if i == m.Selected && isCurrentStep {
 			bullet = ui.BulletStyle.Render("●")
-			content.WriteString(fmt.Sprintf("%s %s %s %s\n", accLine, bullet, ui.SelectedStyle.Render(option), preview))
+			content.WriteString(fmt.Sprintf("%s %s %s %s\n", accLine, bullet, highlightMatches(option, entry.Positions, ui.SelectedStyle), preview))
 		} else {
 			bullet = ui.DimStyle.Render("○")
-			content.WriteString(fmt.Sprintf("%s %s %s %s\n", accLine, bullet, ui.NormalStyle.Render(option), preview))
+			content.WriteString(fmt.Sprintf("%s %s %s %s\n", accLine, bullet, highlightMatches(option, entry.Positions, ui.NormalStyle), preview))
 		}
 	}
 
+	content.WriteString(m.renderListFooter(accLine, start, end, len(entries)))
+
 	return content.String()
 }
 
@@ -485,117 +1157,176 @@ func (m Model) renderOptions(options []string, isCurrentStep bool) string {
 	var content strings.Builder
 	accLine := ui.AccentStyle.Render("│")
 
-	for i, option := range options {
+	entries := m.optionEntries(options, isCurrentStep)
+	if isCurrentStep && m.Filter.Active {
+		content.WriteString(m.renderFilterLine(len(entries), len(options)))
+	}
+
+	start, end := m.optionWindow(len(entries), isCurrentStep)
+	content.WriteString(renderScrollIndicator(accLine, "↑", start, ""))
+
+	multiSelect := isCurrentStep && m.isMultiSelectStep()
+
+	for i := start; i < end; i++ {
+		entry := entries[i]
 		var bullet string
-		if i == m.Selected && isCurrentStep {
+		option := options[entry.Index]
+		if multiSelect && m.isMarked(option) {
+			bullet = ui.AccentStyle.Render("◉")
+			style := ui.NormalStyle
+			if i == m.Selected {
+				bullet = ui.BulletStyle.Render("◉")
+				style = ui.SelectedStyle
+			}
+			content.WriteString(fmt.Sprintf("%s %s %s\n", accLine, bullet, highlightMatches(option, entry.Positions, style)))
+		} else if i == m.Selected && isCurrentStep {
 			bullet = ui.BulletStyle.Render("●")
-			content.WriteString(fmt.Sprintf("%s %s %s\n", accLine, bullet, ui.SelectedStyle.Render(option)))
+			content.WriteString(fmt.Sprintf("%s %s %s\n", accLine, bullet, highlightMatches(option, entry.Positions, ui.SelectedStyle)))
 		} else {
 			bullet = ui.DimStyle.Render("○")
-			content.WriteString(fmt.Sprintf("%s %s %s\n", accLine, bullet, ui.NormalStyle.Render(option)))
+			content.WriteString(fmt.Sprintf("%s %s %s\n", accLine, bullet, highlightMatches(option, entry.Positions, ui.NormalStyle)))
 		}
 	}
 
+	content.WriteString(m.renderListFooter(accLine, start, end, len(entries)))
+
 	return content.String()
 }
 
-func (m Model) renderTagInput() string {
-	var content strings.Builder
-	cursor := "_"
-	line := ui.LineStyle.Render("│")
-	inputText := m.TagModel.ManualInput
-
-	if m.Success == "" {
-		line = ui.AccentStyle.Render("│")
-		content.WriteString(line + " " + ui.NormalStyle.Render("Enter tag name:") + "\n")
+// optionWindow returns the range of options that fits on the screen.
+// Only the list of the current step scrolls, completed lists are not shown anyway.
+func (m Model) optionWindow(total int, isCurrentStep bool) (int, int) {
+	if !isCurrentStep {
+		return 0, total
 	}
+	return scrollWindow(total, m.listHeight(), m.Selected)
+}
 
-	// Add cursor at the end
-	displayText := inputText + cursor
-
-	if m.Success == "" {
-		content.WriteString(line + " " + ui.AccentStyle.Render("> ") + displayText + "\n")
-		content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
-	} else {
-		content.WriteString(line + " " + ui.CompletedStyle.Render("> "+inputText) + "\n")
+// renderListFooter shows the hidden options below and the "x of y" counter for clipped lists
+func (m Model) renderListFooter(line string, start int, end int, total int) string {
+	if start == 0 && end == total {
+		return ""
 	}
+	return renderScrollIndicator(line, "↓", total-end, fmt.Sprintf("%d of %d", m.Selected+1, total))
+}
 
-	return content.String()
+// optionEntries returns the options to render, filtered if the filter is active for this list
+func (m Model) optionEntries(options []string, isCurrentStep bool) []filterMatch {
+	if isCurrentStep && m.Filter.Active {
+		return filterOptions(m.Filter.Query, options)
+	}
+	entries := make([]filterMatch, len(options))
+	for i := range options {
+		entries[i] = filterMatch{Index: i}
+	}
+	return entries
 }
 
-// TODO: maybe combine this and above into one, a lot common
-func (m Model) renderBranchInput() string {
-	var content strings.Builder
-	cursor := "_"
-	line := ui.LineStyle.Render("│")
-	inputText := m.BranchModel.Input
+// renderFilterLine shows the filter query and the number of matches
+func (m Model) renderFilterLine(matches int, total int) string {
+	accLine := ui.AccentStyle.Render("│")
+	query := ui.AccentStyle.Render("/ ") + ui.TextStyle.Render(m.Filter.Query) + ui.AccentStyle.Render("▏")
 
-	if m.Success == "" {
-		line = ui.AccentStyle.Render("│")
-		content.WriteString(line + " " + ui.NormalStyle.Render("Enter branch name:") + "\n")
+	if matches == 0 {
+		return fmt.Sprintf("%s %s  %s\n", accLine, query, ui.PeachStyle.Render("no matches"))
 	}
+	return fmt.Sprintf("%s %s  %s\n", accLine, query, ui.DimStyle.Render(fmt.Sprintf("%d of %d", matches, total)))
+}
 
-	// Add cursor at the end
-	displayText := inputText + cursor
-
-	if m.Success == "" {
-		content.WriteString(line + " " + ui.AccentStyle.Render("> ") + displayText + "\n")
-		content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
-	} else {
-		content.WriteString(line + " " + ui.CompletedStyle.Render("> "+inputText) + "\n")
+// highlightMatches renders an option with the runes matched by the filter highlighted
+func highlightMatches(option string, positions []int, style lipgloss.Style) string {
+	if len(positions) == 0 {
+		return style.Render(option)
 	}
 
+	var content strings.Builder
+	next := 0
+	for i, r := range []rune(option) {
+		if next < len(positions) && positions[next] == i {
+			content.WriteString(ui.AccentStyle.Bold(true).Underline(true).Render(string(r)))
+			next++
+		} else {
+			content.WriteString(style.Render(string(r)))
+		}
+	}
 	return content.String()
 }
 
-func (m Model) renderCommitMessageInput() string {
+func (m Model) renderTagInput() string {
+	return m.renderInput("Enter tag name:", m.TagModel.ManualInput)
+}
+
+// renderTemplateInput renders the fields of a branch template with a preview of the resulting name
+func (m Model) renderTemplateInput() string {
 	var content strings.Builder
-	cursor := "_"
-	line := ui.LineStyle.Render("│")
-	inputText := m.CommitModel.CommitMessage
+	line := ui.AccentStyle.Render("│")
 
-	if m.Success == "" {
-		line = ui.AccentStyle.Render("│")
-		content.WriteString(line + " " + ui.NormalStyle.Render("Enter commit message:") + "\n")
+	content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.DimStyle.Render(m.BranchModel.Template) + "\n")
+	for i, value := range m.BranchModel.FieldValues {
+		if value == "" {
+			value = "-"
+		}
+		content.WriteString(line + " " + ui.DimStyle.Render(m.BranchModel.TemplateFields[i]+": ") + ui.CompletedStyle.Render(value) + "\n")
 	}
 
-	// Add cursor at the end
-	displayText := inputText + cursor
+	preview := fillBranchTemplate(m.BranchModel.Template, m.templateValues())
+	content.WriteString(line + " " + ui.DimStyle.Render("→ ") + ui.NormalStyle.Render(preview) + "\n")
 
-	if m.Success == "" {
-		content.WriteString(line + " " + ui.AccentStyle.Render("> ") + displayText + "\n")
-		content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
-	} else {
-		content.WriteString(line + " " + ui.CompletedStyle.Render("> "+inputText) + "\n")
+	field := m.BranchModel.TemplateFields[len(m.BranchModel.FieldValues)]
+	if field == "slug" {
+		field = "title"
 	}
+	content.WriteString(m.renderInput("Enter "+field+":", m.BranchModel.FieldInput))
+	return content.String()
+}
 
+func (m Model) renderBranchInput() string {
+	return m.renderInput("Enter branch name:", m.BranchModel.Input)
+}
+
+func (m Model) renderCommitMessageInput() string {
+	var content strings.Builder
+	if m.addsTicketFooter() && m.Success == "" {
+		content.WriteString(ui.AccentStyle.Render("├╌") + " " + ui.DimStyle.Render("Adds the footer ") + ui.NormalStyle.Render("Refs: "+m.CommitModel.Ticket) + "\n")
+	}
+	content.WriteString(m.renderInput("Enter commit message:", m.CommitModel.CommitMessage))
 	return content.String()
 }
 
 func (m Model) renderRemoteInput() string {
+	if m.CurrentStep == StepRemoteNameInput {
+		return m.renderInput("", m.RemoteModel.NameInput)
+	}
+	return m.renderInput("", m.RemoteModel.UrlInput)
+}
+
+// renderInput renders the active text input with its placeholder and validation error,
+// or the submitted value once the step is completed.
+func (m Model) renderInput(label string, value string) string {
 	var content strings.Builder
-	var inputText string
 
-	cursor := "_"
-	line := ui.LineStyle.Render("│")
-	accentLine := ui.AccentStyle.Render("│")
+	if m.Success != "" {
+		line := ui.LineStyle.Render("│")
+		content.WriteString(line + " " + ui.CompletedStyle.Render("> "+value) + "\n")
+		return content.String()
+	}
 
-	if m.CurrentStep == StepRemoteNameInput {
-		inputText = m.RemoteModel.NameInput
-	} else {
-		inputText = m.RemoteModel.UrlInput
+	line := ui.AccentStyle.Render("│")
+	if label != "" {
+		content.WriteString(line + " " + ui.NormalStyle.Render(label) + "\n")
 	}
 
-	// Add cursor at the end
-	displayText := inputText + cursor
+	input := m.Input.Field.View()
+	if m.Input.Field.Value() == "" && m.Input.Placeholder != "" {
+		input += ui.DimStyle.Render(m.Input.Placeholder)
+	}
+	content.WriteString(line + " " + input + "\n")
 
-	if m.Success == "" {
-		content.WriteString(accentLine + " " + ui.AccentStyle.Render("> ") + displayText + "\n")
-		content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
-	} else {
-		content.WriteString(line + " " + ui.CompletedStyle.Render("> "+inputText) + "\n")
+	if err := m.inputError(); err != nil {
+		content.WriteString(line + " " + ui.PeachStyle.Render(err.Error()) + "\n")
 	}
 
+	content.WriteString(ui.AccentStyle.Render("╰─╌") + "\n")
 	return content.String()
 }
 
@@ -611,39 +1342,18 @@ func (m Model) renderOutput(line string, level int) string {
 		return ""
 	}
 
+	return m.renderOutputText(line, m.Output[level])
+}
+
+// renderOutputText renders a block of output, lines can start with a color code (e.g. "\\cgSuccess!")
+func (m Model) renderOutputText(line string, output string) string {
 	var content strings.Builder
-	outputLines := strings.Split(m.Output[level], "\n")
+	outputLines := strings.Split(output, "\n")
 
 	var renderedLines []string
 	for _, outputLine := range outputLines {
-		trimmed := strings.TrimSpace(outputLine)
-		if trimmed != "" {
-			// Check for color prefix (e.g., "\cgSuccess!")
-			if coloredText, found := strings.CutPrefix(trimmed, "\\c"); found && len(coloredText) > 1 {
-				color := coloredText[:1]
-				text := coloredText[1:]
-
-				switch color {
-				case "g", "s":
-					renderedLines = append(renderedLines, line+" "+ui.GreenStyle.Render(text))
-				case "y":
-					renderedLines = append(renderedLines, line+" "+ui.YellowStyle.Render(text))
-				case "p", "w":
-					renderedLines = append(renderedLines, line+" "+ui.PeachStyle.Render(text))
-				case "r", "e":
-					renderedLines = append(renderedLines, line+" "+ui.RedStyle.Render(text))
-				case "t":
-					renderedLines = append(renderedLines, line+" "+ui.TextStyle.Render(text))
-				case "a":
-					renderedLines = append(renderedLines, line+" "+ui.AccentStyle.Render(text))
-				default:
-					// Fallback for unknown color codes
-					// TODO: add all colors & maybe simplify
-					renderedLines = append(renderedLines, line+" "+ui.DimStyle.Render(outputLine))
-				}
-			} else {
-				renderedLines = append(renderedLines, line+" "+ui.DimStyle.Render(outputLine))
-			}
+		if strings.TrimSpace(outputLine) != "" {
+			renderedLines = append(renderedLines, line+" "+colorize(outputLine, ui.DimStyle))
 		}
 	}
 
@@ -651,11 +1361,80 @@ func (m Model) renderOutput(line string, level int) string {
 		return line + "\n"
 	}
 
+	// Scroll long output while gith keeps running, once a flow quits
+	// everything is printed to stay in the terminal
+	running := m.Persistent || (m.Err == "" && m.Success == "")
+	var above, below int
+	if running && m.outputHeight() > 0 && len(renderedLines) > m.outputHeight() {
+		height := m.outputHeight()
+		start := min(m.OutputScroll, len(renderedLines)-height)
+		above, below = start, len(renderedLines)-start-height
+		renderedLines = renderedLines[start : start+height]
+	}
+
 	content.WriteString(line + "\n")
+	content.WriteString(renderScrollIndicator(line, "↑", above, ""))
 	for _, l := range renderedLines {
 		content.WriteString(l + "\n")
 	}
+	if above > 0 || below > 0 {
+		content.WriteString(renderScrollIndicator(line, "↓", below, fmt.Sprintf("%s / %s to scroll", keyHint(m.Keys.OutputUp, false), keyHint(m.Keys.OutputDown, false))))
+	}
+	content.WriteString(line + "\n")
+
+	return content.String()
+}
+
+// colorize renders a line that can start with a color code (e.g. "\\cgSuccess!"),
+// lines without a color code are rendered with the fallback style
+func colorize(outputLine string, fallback lipgloss.Style) string {
+	coloredText, found := strings.CutPrefix(strings.TrimSpace(outputLine), "\\c")
+	if !found || len(coloredText) <= 1 {
+		return fallback.Render(outputLine)
+	}
+
+	color := coloredText[:1]
+	text := coloredText[1:]
+
+	switch color {
+	case "g", "s":
+		return ui.GreenStyle.Render(text)
+	case "y":
+		return ui.YellowStyle.Render(text)
+	case "p", "w":
+		return ui.PeachStyle.Render(text)
+	case "r", "e":
+		return ui.RedStyle.Render(text)
+	case "t":
+		return ui.TextStyle.Render(text)
+	case "a":
+		return ui.AccentStyle.Render(text)
+	default:
+		// Fallback for unknown color codes
+		// TODO: add all colors & maybe simplify
+		return ui.DimStyle.Render(outputLine)
+	}
+}
+
+// renderLastResult shows the result of the previous flow in persistent mode
+func (m Model) renderLastResult() string {
+	if m.LastResult.Title == "" && m.LastResult.Err == "" && m.LastResult.Success == "" {
+		return ""
+	}
+
+	var content strings.Builder
+	line := ui.LineStyle.Render("│")
+
 	content.WriteString(line + "\n")
+	content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.DimStyle.Render("Last: "+m.LastResult.Title) + "\n")
+	if strings.TrimSpace(m.LastResult.Output) != "" {
+		content.WriteString(m.renderOutputText(line, m.LastResult.Output))
+	}
+	if m.LastResult.Err != "" {
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.ErrorStyle.Render(m.LastResult.Err) + "\n")
+	} else if m.LastResult.Success != "" {
+		content.WriteString(ui.LineStyle.Render("├╌") + " " + ui.SuccessStyle.Render(m.LastResult.Success) + "\n")
+	}
 
 	return content.String()
 }
@@ -670,15 +1449,39 @@ func (m Model) renderResult() string {
 	return ""
 }
 
-// renderNavigationHints displays help text for the user.
+// renderNavigationHints displays help text for the user, based on the active keymap.
 func (m Model) renderNavigationHints() string {
+	back := keyHint(m.Keys.Back, isInputStep(m.CurrentStep))
+	quit := keyHint(m.Keys.Quit, isInputStep(m.CurrentStep))
+	selectKey := keyHint(m.Keys.Select, isInputStep(m.CurrentStep))
+	navigate := navigationHint(m.Keys.Up, m.Keys.Down)
+
+	if m.Filter.Active {
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Type to filter, %s to navigate, %s to select, %s to clear filter", navigate, selectKey, keyHint(m.Keys.Quit, true)))
+	}
+
 	switch m.CurrentStep {
-	case StepTagInput, StepBranchInput, StepRemoteNameInput, StepRemoteUrlInput:
-		return "\n\n" + ui.DimStyle.Render("Type name, enter to confirm, ctrl+h to go back, esc to quit")
+	case StepTagInput, StepBranchInput, StepBranchTemplateInput, StepBranchBaseInput, StepRemoteNameInput, StepRemoteUrlInput, StepCommitInput, StepChangesRangeInput, StepFileHistoryInput:
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Type to edit, %s to confirm, %s to go back, %s to quit", selectKey, back, quit))
+	case StepBlame:
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to navigate, %s for the commit, %s to blame its parent, %s to go back, %s to quit", navigate, selectKey, keyHint(m.Keys.Parent, false), back, quit))
+	case StepCommitDetails:
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to scroll, %s to go back, %s to quit", navigate, back, quit))
+	case StepDiff:
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to scroll, %s for files, %s to go back, %s to quit", navigate, navigationHint(m.Keys.PrevFile, m.Keys.NextFile), back, quit))
 	case StepOptionsAccentSelect:
-		return "\n\n" + ui.DimStyle.Render("Select Accent to preview, enter to confirm, ctrl+h to go back, esc to quit")
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Select Accent to preview, %s to confirm, %s to go back, %s to quit", selectKey, back, quit))
 	default:
-		return "\n\n" + ui.DimStyle.Render("Use ↑↓ to navigate, enter to select, ctrl+h to go back, q / esc to quit")
+		if m.isMultiSelectStep() {
+			return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to navigate, %s to mark, %s to select, %s to filter, %s to go back, %s to quit", navigate, keyHint(m.Keys.Toggle, false), selectKey, keyHint(m.Keys.Filter, false), back, quit))
+		}
+		if m.isSwitchBranchStep() {
+			return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to navigate, %s to select, %s for previous, %s to filter, %s to go back, %s to quit", navigate, selectKey, keyHint(m.Keys.Previous, false), keyHint(m.Keys.Filter, false), back, quit))
+		}
+		if m.isFilterableStep() {
+			return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to navigate, %s to select, %s to filter, %s to go back, %s to quit", navigate, selectKey, keyHint(m.Keys.Filter, false), back, quit))
+		}
+		return "\n\n" + ui.DimStyle.Render(fmt.Sprintf("Use %s to navigate, %s to select, %s to go back, %s to quit", navigate, selectKey, back, quit))
 	}
 }
 
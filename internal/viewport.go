@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a3chron/gith/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listHeight returns how many options fit on the screen, based on the
+// terminal height and the lines used by the completed steps above the list.
+// Without a known terminal height all options are shown.
+func (m Model) listHeight() int {
+	if m.Height == 0 {
+		return 0
+	}
+	// padding, header with repository state, completed levels, list border, indicators and hints
+	reserved := 13 + 3*m.Level
+	return max(m.Height-reserved, 3)
+}
+
+// outputHeight returns how many output lines are shown before the output scrolls
+func (m Model) outputHeight() int {
+	if m.Height == 0 {
+		return 0
+	}
+	return max(m.Height/3, 5)
+}
+
+// outputLineCount returns the number of lines of the longest output block
+func (m Model) outputLineCount() int {
+	longest := 0
+	for _, output := range append([]string{m.LastResult.Output}, m.Output...) {
+		count := 0
+		for line := range strings.SplitSeq(output, "\n") {
+			if strings.TrimSpace(line) != "" {
+				count++
+			}
+		}
+		longest = max(longest, count)
+	}
+	return longest
+}
+
+// scrollOutput scrolls long output by a line, a page or to its start or end
+func (m *Model) scrollOutput(msg tea.KeyMsg) {
+	lastScroll := max(m.outputLineCount()-m.outputHeight(), 0)
+
+	switch {
+	case key.Matches(msg, m.Keys.Up, m.Keys.OutputUp):
+		m.OutputScroll = max(m.OutputScroll-1, 0)
+	case key.Matches(msg, m.Keys.Down, m.Keys.OutputDown):
+		m.OutputScroll = min(m.OutputScroll+1, lastScroll)
+	case key.Matches(msg, m.Keys.PageUp):
+		m.OutputScroll = max(m.OutputScroll-m.outputHeight(), 0)
+	case key.Matches(msg, m.Keys.PageDown):
+		m.OutputScroll = min(m.OutputScroll+m.outputHeight(), lastScroll)
+	case key.Matches(msg, m.Keys.Home):
+		m.OutputScroll = 0
+	case key.Matches(msg, m.Keys.End):
+		m.OutputScroll = lastScroll
+	}
+}
+
+// pageSize is the number of options skipped by page up / page down
+func (m Model) pageSize() int {
+	if height := m.listHeight(); height > 0 {
+		return height
+	}
+	return 10
+}
+
+// scrollWindow returns the visible range [start, end) of a list with total
+// entries, keeping focus roughly in the middle. height 0 shows everything.
+func scrollWindow(total int, height int, focus int) (int, int) {
+	if height <= 0 || total <= height {
+		return 0, total
+	}
+	start := min(max(focus-height/2, 0), total-height)
+	return start, start + height
+}
+
+// renderScrollIndicator shows how many entries are hidden above or below the visible range
+func renderScrollIndicator(line string, arrow string, hidden int, position string) string {
+	if hidden <= 0 {
+		if position != "" {
+			return fmt.Sprintf("%s   %s\n", line, ui.DimStyle.Render(position))
+		}
+		return ""
+	}
+
+	text := fmt.Sprintf("%s %d more", arrow, hidden)
+	if position != "" {
+		text += "  " + position
+	}
+	return fmt.Sprintf("%s   %s\n", line, ui.DimStyle.Render(text))
+}
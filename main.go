@@ -21,6 +21,9 @@ var (
 	builtBy = "unknown"
 )
 
+// skipConfirm is set by --yes and skips all confirmations for this run
+var skipConfirm bool
+
 func getVersion() string {
 	// If version was set by GoReleaser, use it
 	if version != "dev" {
@@ -46,16 +49,23 @@ func initialModel(cfg *config.Config) internal.Model {
 		CurrentStep: internal.StepLoad,
 		Loading:     true,
 		ActionModel: internal.ActionModel{
-			Actions: []string{"Branch", "Status", "Commit", "Tag", "Remote", "Changes", "Options"},
+			Actions: []string{"Branch", "Status", "Commit", "Tag", "Remote", "Changes", "History", "Options"},
 		},
 		BranchModel: internal.BranchModel{
-			Actions: []string{"Switch Branch", "Create Branch", "List Branches", "Delete Branch"},
-			Options: []string{"feat/", "fix/", "refactor/", "docs/", "Manual Input"},
+			Actions:       []string{"Switch Branch", "Create Branch", "Rename Branch", "Push Branch", "Tracking", "Compare", "List Branches", "Delete Branch", "Prune Stale Branches", "Clean Up Merged"},
+			Options:       []string{"feat/", "fix/", "refactor/", "docs/", "Manual Input"},
+			SwitchOptions: []string{"Create and switch to it", "Create without switching"},
 		},
 		CommitModel: internal.CommitModel{
 			Actions:        []string{"Commit Staged", "Commit All", "Undo Last Commit"},
 			CommitPrefixes: []string{"feat", "fix", "chore", "build", "ci", "test", "perf", "refactor", "revert", "style", "docs", "Custom Prefix"},
 		},
+		ChangesModel: internal.ChangesModel{
+			Actions: []string{"Unstaged Changes", "Staged Changes", "Commit", "Range"},
+		},
+		HistoryModel: internal.HistoryModel{
+			Actions: []string{"Graph", "Blame", "File History"},
+		},
 		TagModel: internal.TagModel{
 			Actions: []string{"Add Tag", "Remove Tag", "List Tags", "Push Tag"},
 		},
@@ -67,6 +77,10 @@ func initialModel(cfg *config.Config) internal.Model {
 			InitBehaviours: []string{"Always fetch on Init", "Do not fetch for Quick Selects", "Never fetch"},
 		},
 		CurrentConfig: cfg,
+		Keys:          internal.NewKeyMap(cfg.Keymap),
+		Persistent:    cfg.Persistent,
+		SkipConfirm:   cfg.SkipConfirm || skipConfirm,
+		RepoInfo:      internal.RepoInfoModel{Loading: true},
 		Selected:      0,
 		Level:         0,
 		StartAt:       "",
@@ -91,30 +105,42 @@ func main() {
 }
 
 func run() error {
+	os.Args = stripYesFlag(os.Args)
+
 	if len(os.Args) > 1 {
 		return handleCliArgs()
 	}
 
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		// Fall back to defaults if config loading fails
-		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
-		cfg = &config.Config{
-			Accent:        config.DefaultConfig.Accent,
-			Flavor:        config.DefaultConfig.Flavor,
-			InitBehaviour: config.DefaultConfig.InitBehaviour,
+	return runInteractive(false)
+}
+
+// stripYesFlag removes --yes / -y from the arguments, so it can be combined with every command
+func stripYesFlag(args []string) []string {
+	stripped := args[:1]
+	for _, arg := range args[1:] {
+		if arg == "--yes" || arg == "-y" {
+			skipConfirm = true
+			continue
 		}
+		stripped = append(stripped, arg)
 	}
+	return stripped
+}
 
-	// Initialize styles with the loaded config
-	ui.UpdateStylesByConfig(cfg)
+// runInteractive starts the full UI, persistent forces the persistent session mode
+// for this run without changing the config.
+func runInteractive(persistent bool) error {
+	cfg := loadConfig()
 
 	isRepo, err := internal.IsGitRepository()
 	if err != nil || !isRepo {
 		return fmt.Errorf("not in a git repository")
 	}
 
-	p := tea.NewProgram(initialModel(cfg))
+	m := initialModel(cfg)
+	m.Persistent = m.Persistent || persistent
+
+	p := tea.NewProgram(m)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run program: %w", err)
 	}
@@ -124,18 +150,7 @@ func run() error {
 
 // runQuick starts the UI directly at a specific flow (e.g., add-tag).
 func runQuick(startAt string, startAtLevel int) error {
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		// Fall back to defaults if config loading fails
-		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
-		cfg = &config.Config{
-			Accent: config.DefaultConfig.Accent,
-			Flavor: config.DefaultConfig.Flavor,
-		}
-	}
-
-	// Initialize styles with the loaded config
-	ui.UpdateStylesByConfig(cfg)
+	cfg := loadConfig()
 
 	isRepo, err := internal.IsGitRepository()
 	if err != nil || !isRepo {
@@ -149,6 +164,24 @@ func runQuick(startAt string, startAtLevel int) error {
 	return nil
 }
 
+// loadConfig loads the config for the UI and initializes the styles with it.
+// Problems are printed as warnings, falling back to defaults.
+func loadConfig() *config.Config {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		// Fall back to defaults if config loading fails
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config, using defaults: %v\n", err)
+		cfg = config.NewDefaultConfig()
+	} else if issues := cfg.Issues(); len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: config has %d invalid or unknown keys, using defaults for them\nRun 'gith config validate' for details\n", len(issues))
+	}
+
+	// Initialize styles with the loaded config
+	ui.UpdateStylesByConfig(cfg)
+
+	return cfg
+}
+
 func handleCliArgs() error {
 	switch os.Args[1] {
 	case "version", "-v", "--version":
@@ -160,6 +193,9 @@ func handleCliArgs() error {
 		internal.PrintHelp()
 		return nil
 
+	case "-i", "--interactive":
+		return runInteractive(true)
+
 	case "update":
 		if version == "dev" {
 			// installed via go install (or actual dev build lol)
@@ -211,8 +247,11 @@ func handleCliArgs() error {
 		return runQuick("add-tag", 3)
 
 	case "switch":
+		if len(os.Args) == 3 && os.Args[2] == "-" {
+			return runQuick("switch-previous", 3)
+		}
 		if len(os.Args) != 2 {
-			fmt.Fprintf(os.Stderr, "Usage: gith switch\n")
+			fmt.Fprintf(os.Stderr, "Usage: gith switch [ - ]\n")
 			os.Exit(1)
 		}
 
@@ -315,6 +354,8 @@ func handleConfigCommand() error {
 		return showConfigPath()
 	case "update":
 		return updateConfig()
+	case "validate":
+		return validateConfig()
 	default:
 		return printConfigUsage()
 	}
@@ -326,8 +367,12 @@ Config commands:
   gith config show     - Show current configuration
   gith config reset    - Reset configuration to defaults
   gith config path     - Show configuration file path
+  gith config validate - Report invalid or unknown keys in the configuration
 
   gith config update [--flavor=<flavor>] [--accent=<accent>] [--initFetch=<initFetch>]
+                     [--persistent=<true|false>] [--skipConfirm=<true|false>]
+                     [--protectedBranches=<branch,...>] [--branchTemplates=<template,...>]
+                     [--ticketPattern=<regex>] [--ticketPlacement=<placement>]
     Update your configuration options. Flags are optional and can be combined.
 
     --flavor=<flavor>
@@ -344,6 +389,63 @@ Config commands:
         always  - always fetch on init
         quick   - fetch only for full load, skip for quick selects
         never   - never fetch on init
+
+    --persistent=<true|false>
+        Return to the action selection after each action instead of quitting.
+        Same as always starting gith with -i.
+
+    --skipConfirm=<true|false>
+        Run destructive operations (delete branch, remove tag, remove remote,
+        undo commit) without asking for confirmation.
+        Same as always passing --yes.
+
+    --protectedBranches=<branch,...>
+        Comma separated branches that are never offered by Branch -> Clean Up Merged.
+        The current and the default branch are always protected.
+        Default: main,master,develop
+
+    --branchTemplates=<template,...>
+        Comma separated name templates offered by Branch -> Create Branch.
+        Every {field} is asked for, {type} suggests the branch prefixes and
+        {slug} asks for a title that is turned into a slug, e.g.
+        {type}/{ticket}-{slug} -> feat/ABC-123-add-login-page
+        Empty fields are left out together with their separator.
+        Default: {type}/{slug}
+
+    --ticketPattern=<regex>
+        Regular expression that finds the ticket ID in the current branch name,
+        e.g. ABC-123 in feat/ABC-123-add-login. If it has a group, the first group is used.
+        Default: [A-Z][A-Z0-9]+-[0-9]+
+
+    --ticketPlacement=<placement>
+        Where Commit puts the ticket ID. Available options:
+        scope   - feat(ABC-123): message
+        prefix  - feat: ABC-123 message
+        footer  - a "Refs: ABC-123" footer (default)
+        off     - don't add the ticket ID
+
+Environment variables:
+  Every option can also be set via an environment variable.
+  These override the config file, but are never written to it.
+
+  GITH_FLAVOR       - same values as --flavor
+  GITH_ACCENT       - same values as --accent
+  GITH_INIT_FETCH   - same values as --initFetch
+  GITH_PERSISTENT   - same values as --persistent
+  GITH_SKIP_CONFIRM - same values as --skipConfirm
+  GITH_PROTECTED_BRANCHES - same values as --protectedBranches
+  GITH_BRANCH_TEMPLATES   - same values as --branchTemplates
+  GITH_TICKET_PATTERN     - same values as --ticketPattern
+  GITH_TICKET_PLACEMENT   - same values as --ticketPlacement
+  GITH_KEYMAP_*     - comma separated keys, e.g. GITH_KEYMAP_QUIT="q,esc"
+
+Keymap:
+  Keys can be rebound in the "keymap" section of the config file, e.g.
+    "keymap": { "up": ["up", "k"], "back": ["ctrl+h", "left"] }
+
+  Actions: up, down, pageUp, pageDown, home, end, select, back, quit, filter,
+           outputUp, outputDown, toggle, previous, nextFile, prevFile, parent
+  Matching env variables: GITH_KEYMAP_UP, GITH_KEYMAP_PAGE_UP, ...
 `
 
 	fmt.Println(helpText)
@@ -358,12 +460,43 @@ func showConfig() error {
 	}
 
 	fmt.Printf("Current configuration:\n")
-	fmt.Printf("  Flavor:         %s\n", cfg.Flavor)
-	fmt.Printf("  Accent:         %s\n", cfg.Accent)
-	fmt.Printf("  Init Behaviour: %s\n", cfg.InitBehaviour)
+	fmt.Printf("  Flavor:         %s%s\n", cfg.Flavor, envSuffix(cfg, "flavor"))
+	fmt.Printf("  Accent:         %s%s\n", cfg.Accent, envSuffix(cfg, "accent"))
+	fmt.Printf("  Init Behaviour: %s%s\n", cfg.InitBehaviour, envSuffix(cfg, "init"))
+	fmt.Printf("  Persistent:     %t%s\n", cfg.Persistent, envSuffix(cfg, "persistent"))
+	fmt.Printf("  Skip Confirm:   %t%s\n", cfg.SkipConfirm, envSuffix(cfg, "skipConfirm"))
+	fmt.Printf("  Protected:      %s%s\n", strings.Join(cfg.ProtectedBranches, ", "), envSuffix(cfg, "protectedBranches"))
+	fmt.Printf("  Templates:      %s%s\n", strings.Join(cfg.BranchTemplates, ", "), envSuffix(cfg, "branchTemplates"))
+	fmt.Printf("  Ticket:         %s in %s%s%s\n", cfg.TicketPlacement, cfg.TicketPattern, envSuffix(cfg, "ticketPlacement"), envSuffix(cfg, "ticketPattern"))
+	fmt.Printf("  Keymap:\n")
+	fmt.Printf("    Up:           %s%s\n", strings.Join(cfg.Keymap.Up, ", "), envSuffix(cfg, "keymap.up"))
+	fmt.Printf("    Down:         %s%s\n", strings.Join(cfg.Keymap.Down, ", "), envSuffix(cfg, "keymap.down"))
+	fmt.Printf("    Page Up:      %s%s\n", strings.Join(cfg.Keymap.PageUp, ", "), envSuffix(cfg, "keymap.pageUp"))
+	fmt.Printf("    Page Down:    %s%s\n", strings.Join(cfg.Keymap.PageDown, ", "), envSuffix(cfg, "keymap.pageDown"))
+	fmt.Printf("    Home:         %s%s\n", strings.Join(cfg.Keymap.Home, ", "), envSuffix(cfg, "keymap.home"))
+	fmt.Printf("    End:          %s%s\n", strings.Join(cfg.Keymap.End, ", "), envSuffix(cfg, "keymap.end"))
+	fmt.Printf("    Select:       %s%s\n", strings.Join(cfg.Keymap.Select, ", "), envSuffix(cfg, "keymap.select"))
+	fmt.Printf("    Back:         %s%s\n", strings.Join(cfg.Keymap.Back, ", "), envSuffix(cfg, "keymap.back"))
+	fmt.Printf("    Quit:         %s%s\n", strings.Join(cfg.Keymap.Quit, ", "), envSuffix(cfg, "keymap.quit"))
+	fmt.Printf("    Filter:       %s%s\n", strings.Join(cfg.Keymap.Filter, ", "), envSuffix(cfg, "keymap.filter"))
+	fmt.Printf("    Output Up:    %s%s\n", strings.Join(cfg.Keymap.OutputUp, ", "), envSuffix(cfg, "keymap.outputUp"))
+	fmt.Printf("    Output Down:  %s%s\n", strings.Join(cfg.Keymap.OutputDown, ", "), envSuffix(cfg, "keymap.outputDown"))
+	fmt.Printf("    Toggle:       %s%s\n", strings.Join(cfg.Keymap.Toggle, ", "), envSuffix(cfg, "keymap.toggle"))
+	fmt.Printf("    Previous:     %s%s\n", strings.Join(cfg.Keymap.Previous, ", "), envSuffix(cfg, "keymap.previous"))
+	fmt.Printf("    Next File:    %s%s\n", strings.Join(cfg.Keymap.NextFile, ", "), envSuffix(cfg, "keymap.nextFile"))
+	fmt.Printf("    Prev File:    %s%s\n", strings.Join(cfg.Keymap.PrevFile, ", "), envSuffix(cfg, "keymap.prevFile"))
+	fmt.Printf("    Parent:       %s%s\n", strings.Join(cfg.Keymap.Parent, ", "), envSuffix(cfg, "keymap.parent"))
 	return nil
 }
 
+// envSuffix marks values that are set by an environment variable
+func envSuffix(cfg *config.Config, key string) string {
+	if env, ok := cfg.EnvOverride(key); ok {
+		return fmt.Sprintf(" (from %s)", env)
+	}
+	return ""
+}
+
 func updateConfig() error {
 	if len(os.Args) <= 3 {
 		return printConfigUsage()
@@ -375,38 +508,100 @@ func updateConfig() error {
 	}
 
 	args := os.Args[3:]
-	for _, arg := range args {
-		arg = strings.ToLower(arg)
+	for _, original := range args {
+		arg := strings.ToLower(original)
 
 		switch {
 		case strings.HasPrefix(arg, "--flavor="):
 			val := strings.TrimPrefix(arg, "--flavor=")
-			if !config.IsValidFlavor(val) {
+			flavor, ok := config.NormalizeFlavor(val)
+			if !ok {
 				return fmt.Errorf("not a valid flavor: %s\nvalid flavors: %s", val, strings.Join(config.GetAvailableFlavors(), ", "))
 			}
-			cfg.Flavor = val
+			cfg.Flavor = flavor
+			cfg.MarkUpdated("flavor")
 
 		case strings.HasPrefix(arg, "--accent="):
 			val := strings.TrimPrefix(arg, "--accent=")
-			if !config.IsValidAccent(val) {
+			accent, ok := config.NormalizeAccent(val)
+			if !ok {
 				return fmt.Errorf("not a valid accent: %s\nvalid accents: %s", val, strings.Join(config.GetAvailableAccents(), ", "))
 			}
-			cfg.Accent = val
+			cfg.Accent = accent
+			cfg.MarkUpdated("accent")
 
 		case strings.HasPrefix(arg, "--initfetch="):
 			val := strings.TrimPrefix(arg, "--initfetch=")
-			valid := map[string]bool{"always": true, "quick": true, "never": true}
-			if !valid[val] {
+			behaviour, ok := config.ParseInitBehaviour(val)
+			if !ok {
 				return fmt.Errorf("not a valid initFetch: %s\nvalid options: always, quick, never", val)
 			}
-			cfg.InitBehaviour = val
+			cfg.InitBehaviour = behaviour
+			cfg.MarkUpdated("init")
+
+		case strings.HasPrefix(arg, "--persistent="):
+			val := strings.TrimPrefix(arg, "--persistent=")
+			persistent, ok := config.ParseBool(val)
+			if !ok {
+				return fmt.Errorf("not a valid persistent value: %s\nvalid options: true, false", val)
+			}
+			cfg.Persistent = persistent == "true"
+			cfg.MarkUpdated("persistent")
+
+		case strings.HasPrefix(arg, "--skipconfirm="):
+			val := strings.TrimPrefix(arg, "--skipconfirm=")
+			skip, ok := config.ParseBool(val)
+			if !ok {
+				return fmt.Errorf("not a valid skipConfirm value: %s\nvalid options: true, false", val)
+			}
+			cfg.SkipConfirm = skip == "true"
+			cfg.MarkUpdated("skipConfirm")
+
+		case strings.HasPrefix(arg, "--protectedbranches="):
+			// branch names are case sensitive, so the value is taken from the original argument
+			val := original[len("--protectedbranches="):]
+			branches, ok := config.ParseList(val)
+			if !ok {
+				return fmt.Errorf("not a valid protectedBranches value: %s\nexpected a comma separated list of branches", val)
+			}
+			cfg.ProtectedBranches = strings.Split(branches, ",")
+			cfg.MarkUpdated("protectedBranches")
+
+		case strings.HasPrefix(arg, "--branchtemplates="):
+			// field names are case sensitive as well
+			val := original[len("--branchtemplates="):]
+			templates, ok := config.ParseBranchTemplates(val)
+			if !ok {
+				return fmt.Errorf("not a valid branchTemplates value: %s\nexpected a comma separated list of templates like {type}/{ticket}-{slug}", val)
+			}
+			cfg.BranchTemplates = strings.Split(templates, ",")
+			cfg.MarkUpdated("branchTemplates")
+
+		case strings.HasPrefix(arg, "--ticketpattern="):
+			// patterns are case sensitive
+			val := original[len("--ticketpattern="):]
+			pattern, ok := config.ParseTicketPattern(val)
+			if !ok {
+				return fmt.Errorf("not a valid ticketPattern: %s\nexpected a regular expression", val)
+			}
+			cfg.TicketPattern = pattern
+			cfg.MarkUpdated("ticketPattern")
+
+		case strings.HasPrefix(arg, "--ticketplacement="):
+			val := strings.TrimPrefix(arg, "--ticketplacement=")
+			placement, ok := config.ParseTicketPlacement(val)
+			if !ok {
+				return fmt.Errorf("not a valid ticketPlacement: %s\nvalid options: %s", val, strings.Join(config.GetTicketPlacements(), ", "))
+			}
+			cfg.TicketPlacement = placement
+			cfg.MarkUpdated("ticketPlacement")
 
 		default:
 			return fmt.Errorf("'%s' is not a valid flag\nRun 'gith config help' to see valid flags", strings.Split(arg, "=")[0])
 		}
 	}
 
-	saveErr := config.SaveConfig(cfg)
+	saveErr := config.SaveUpdates(cfg)
 	if saveErr != nil {
 		return fmt.Errorf("failed to save config: %w", saveErr)
 	}
@@ -415,6 +610,24 @@ func updateConfig() error {
 	return nil
 }
 
+func validateConfig() error {
+	issues, err := config.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("Configuration is valid")
+		return nil
+	}
+
+	fmt.Printf("Found %d problems in configuration:\n", len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+	return fmt.Errorf("invalid configuration")
+}
+
 func resetConfig() error {
 	if err := config.SaveConfig(&config.DefaultConfig); err != nil {
 		return fmt.Errorf("failed to reset config: %w", err)